@@ -3,43 +3,228 @@ package analysis
 import (
 	"math"
 	"sort"
-	"strconv"
+	"time"
 
 	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
 )
 
-const SHARES_PER_CONTRACT = 100
-
 // AnalyzeContracts performs the analysis on the given options contracts
 func AnalyzeContracts(contracts []model.OptionsContract) model.Analysis {
-	// Sort the contracts by strike price
+	analysis, _ := AnalyzeContractsWithFees(contracts, model.FeeSchedule{})
+	return analysis
+}
+
+// AnalyzeContractsWithFees performs the analysis on the given options contracts net of the fees.
+// The payoff profile is returned so it is not built twice.
+func AnalyzeContractsWithFees(contracts []model.OptionsContract, fees model.FeeSchedule) (model.Analysis, PayoffProfile) {
+	sortContracts(contracts)
+
+	// Build the exact payoff of the contracts at expiry
+	profile := BuildNetPayoffProfile(contracts, fees)
+	analysis := analyzeProfile(contracts, profile, func(price float64) float64 {
+		return CalculateNetProfit(contracts, fees, price)
+	})
+	return analysis, profile
+}
+
+// AnalyzeContractsAtDate performs the analysis on the given options contracts net of the fees at the evaluation date,
+// valuing the contracts that expire after it with Black-Scholes. The sampled profile is returned so it is not built twice.
+func AnalyzeContractsAtDate(contracts []model.OptionsContract, fees model.FeeSchedule, market pricing.Market, at time.Time) (model.Analysis, ModelProfile) {
+	sortContracts(contracts)
+
+	// Sample the model value of the contracts at the evaluation date
+	profile := BuildModelProfile(contracts, fees, market, at)
+	analysis := analyzeProfile(contracts, profile, profile.ProfitLoss)
+	analysis.EvaluationDate = &at
+	return analysis, profile
+}
+
+// sortContracts sorts the contracts by strike price, or cost basis for the underlying
+func sortContracts(contracts []model.OptionsContract) {
 	sort.Slice(contracts, func(i, j int) bool {
-		return contracts[i].StrikePrice < contracts[j].StrikePrice
+		return contracts[i].ReferencePrice() < contracts[j].ReferencePrice()
 	})
+}
+
+// analyzeProfile builds the analysis of a set of sorted contracts from their profit/loss profile
+func analyzeProfile(contracts []model.OptionsContract, profile Profile, profitLoss func(float64) float64) model.Analysis {
 	// Get the Price Range
 	minPrice, maxPrice := DeterminePriceRange(contracts)
-	priceStep := (maxPrice - minPrice) / 30
 	var riskRewardGraph []model.RiskRewardGraph
 	// Go through every price and calculate the total profit at that price
-	for price := minPrice; price <= maxPrice; price += priceStep {
-		profit := MultiplyBySharesAmount(CalculateTotalProfit(contracts, price), SHARES_PER_CONTRACT)
+	for _, price := range DetermineGraphPrices(contracts, minPrice, maxPrice) {
+		profit := roundNearestHundredth(profitLoss(price))
 		riskRewardGraph = append(riskRewardGraph, model.RiskRewardGraph{UnderlyingPrice: price, ProfitLoss: profit})
 	}
 
 	// Calculate the break-even points
-	breakEvenPoints := CalculateBreakEvenPoints(contracts)
+	breakEvenPoints := breakEvenPointsFromProfile(profile)
 
-	// Calculate the maximum profit and maximum loss from the graph
-	maxProfit, maxLoss := CalculateMaxLossAndProfit(contracts)
-	maxLossStr := strconv.FormatFloat(maxLoss, 'f', 2, 64)
-	maxProfitStr := strconv.FormatFloat(maxProfit, 'f', 2, 64)
+	// Calculate the maximum profit and maximum loss and where they occur
+	maxProfit, maxLoss, maxProfitPrices, maxLossPrices := profile.Extremes()
+	maxLossStr := formatProfitLoss(maxLoss)
+	maxProfitStr := formatProfitLoss(maxProfit)
+
+	// Calculate the net premium paid or received to open the position
+	netPremium := CalculateEntryPoint(contracts)
 
 	return model.Analysis{
 		RiskRewardGraph: riskRewardGraph,
 		MaxProfit:       maxProfitStr,
 		MaxLoss:         maxLossStr,
+		MaxProfitPrices: maxProfitPrices,
+		MaxLossPrices:   maxLossPrices,
 		BreakEvenPoints: breakEvenPoints,
+		NetPremium:      netPremium,
+		PremiumType:     DeterminePremiumType(netPremium),
+		Strategy:        ClassifyStrategy(contracts),
+		Metrics:         CalculateRiskMetrics(contracts, maxProfit, maxLoss, netPremium),
+	}
+}
+
+// AnalyzeRequest performs the analysis on the request contracts and adds the model-based results when a volatility is given.
+// The clock tells the valuation time when the request gives none
+func AnalyzeRequest(request model.AnalysisRequest, clock model.Clock) model.Analysis {
+	analysis, _ := analyzeRequest(request, clock)
+	return analysis
+}
+
+// AnalyzeRequestV2 performs the analysis on the request contracts in the second version of the response,
+// with the maximum profit and maximum loss as numbers that are left out and flagged when unbounded
+func AnalyzeRequestV2(request model.AnalysisRequest, clock model.Clock) model.AnalysisV2 {
+	analysis, profile := analyzeRequest(request, clock)
+	maxProfit, maxLoss, _, _ := profile.Extremes()
+	return model.AnalysisV2{
+		Analysis:  analysis,
+		MaxProfit: boundedProfitLoss(maxProfit),
+		MaxLoss:   boundedProfitLoss(maxLoss),
+	}
+}
+
+// analyzeRequest performs the analysis on the request contracts along with the profile its results come from,
+// the contracts given as OCC symbols having been resolved when the request was bound
+func analyzeRequest(request model.AnalysisRequest, clock model.Clock) (model.Analysis, Profile) {
+	// The legs of the warnings are the indices of the contracts as requested, so check them before they are sorted
+	_, warnings := model.CheckSanity(request)
+	warnings = append(warnings, model.MixedExpirationWarnings(request)...)
+
+	// Price every contract with the same fill mode, natural by default
+	fillMode := request.FillMode
+	if fillMode == "" {
+		fillMode = model.Natural
+	}
+	ApplyFillMode(request.Contracts, fillMode)
+
+	// Without a fee schedule the position is analyzed free of fees
+	var fees model.FeeSchedule
+	if request.Fees != nil {
+		fees = *request.Fees
+	}
+
+	// Every model calculation values the contracts in the same market from the same valuation time
+	context, market, now := requestMarket(request, clock)
+
+	// Contracts expiring on different dates are evaluated at the nearest expiration, or at the requested date
+	evaluationDate, atDate := DetermineEvaluationDate(request)
+	var analysis model.Analysis
+	var profile Profile
+	if atDate {
+		analysis, profile = AnalyzeContractsAtDate(request.Contracts, fees, market, evaluationDate)
+	} else {
+		analysis, profile = AnalyzeContractsWithFees(request.Contracts, fees)
+	}
+	analysis.FillMode = fillMode
+	analysis.Warnings = warnings
+	if request.Fees != nil {
+		var feeAnalysis model.FeeAnalysis
+		if atDate {
+			feeAnalysis = CalculateFeeAnalysisAtDate(request.Contracts, fees, market, evaluationDate)
+		} else {
+			feeAnalysis = CalculateFeeAnalysis(request.Contracts, fees)
+		}
+		analysis.Fees = &feeAnalysis
+	}
+
+	// Every option settles at an exact instant on the exchange calendar
+	analysis.Expirations = CalculateExpirations(request.Contracts, now)
+
+	// The margin ties up capital against the maximum profit of the position
+	maxProfit, _, _, _ := profile.Extremes()
+	margin := CalculateMargin(request.Contracts, context.UnderlyingPrice, maxProfit)
+	analysis.Margin = &margin
+
+	// The theoretical curves need a volatility to price the contracts before expiry
+	if market.HasVolatility() {
+		prices := make([]float64, 0, len(analysis.RiskRewardGraph))
+		for _, point := range analysis.RiskRewardGraph {
+			prices = append(prices, point.UnderlyingPrice)
+		}
+		analysis.TheoreticalCurves = CalculateTheoreticalCurves(request, prices, market, now)
+	}
+
+	// The greeks are only meaningful around the current underlying price
+	if market.HasVolatility() && context.UnderlyingPrice > 0 {
+		greeks := CalculatePositionGreeks(request.Contracts, context.UnderlyingPrice, market, now)
+		analysis.Greeks = &greeks
+	}
+
+	// The early-exercise boundaries of the american options come out of the same trees that value them
+	if market.HasVolatility() {
+		analysis.ExerciseBoundaries = CalculateExerciseBoundaries(request.Contracts, context.UnderlyingPrice, market, now)
+	}
+
+	// The probabilities spread the underlying price from the spot until the evaluation date, the common expiration by default
+	horizon, hasHorizon := evaluationDate, atDate
+	if !atDate {
+		horizon, hasHorizon = NearestExpiration(request.Contracts)
+	}
+	if hasHorizon && horizon.After(now) && market.HasVolatility() && context.UnderlyingPrice > 0 {
+		probabilities := CalculateProbabilities(profile, context.UnderlyingPrice, market, now, horizon)
+		analysis.Probabilities = &probabilities
+
+		// The simulation follows the position along the way to manage it before the evaluation date
+		if request.Simulation != nil {
+			simulation := SimulateContracts(request.Contracts, fees, profile, *request.Simulation, context.UnderlyingPrice, market, now, horizon)
+			analysis.Simulation = &simulation
+		}
+	}
+
+	// The implied volatilities are backed out of the quotes around the current underlying price
+	if context.UnderlyingPrice > 0 {
+		analysis.ImpliedVolatilities = CalculateImpliedVolatilities(request.Contracts, context.UnderlyingPrice, context.Carry, now)
+	}
+
+	return analysis, profile
+}
+
+// requestMarket resolves the context of a request and the market and valuation time, the time of the clock by default, its contracts are valued with
+func requestMarket(request model.AnalysisRequest, clock model.Clock) (model.AnalysisContext, pricing.Market, time.Time) {
+	context := request.ResolveContext()
+	market := pricing.Market{Volatility: request.Volatility, Carry: context.Carry}
+	if request.VolatilitySurface != nil {
+		market.Surface = pricing.NewSurface(*request.VolatilitySurface)
+	}
+	return context, market, request.ValuationTime(clock)
+}
+
+// DetermineGraphPrices calculates the graph prices, 30 even steps across the price range plus every strike so no kink is missed
+func DetermineGraphPrices(contracts []model.OptionsContract, minPrice, maxPrice float64) []float64 {
+	var prices []float64
+	priceStep := (maxPrice - minPrice) / 30
+	for price := minPrice; price <= maxPrice; price += priceStep {
+		prices = append(prices, price)
+	}
+
+	// Add the strikes that do not already fall on a step
+	for _, strike := range distinctReferencePrices(contracts) {
+		index := sort.SearchFloat64s(prices, strike-vertexTolerance)
+		if index < len(prices) && math.Abs(prices[index]-strike) <= vertexTolerance {
+			continue
+		}
+		prices = append(prices[:index], append([]float64{strike}, prices[index:]...)...)
 	}
+	return prices
 }
 
 // DeterminePriceRange calculates the price range for a set of options contracts
@@ -47,7 +232,7 @@ func DeterminePriceRange(contracts []model.OptionsContract) (float64, float64) {
 	const delta = 20  // A constant delta value for the buffer
 	const beta = 0.20 // A constant beta value for the buffer
 	// Initialize minStrike and maxStrike using the first and last contract's strike price
-	minStrike, maxStrike := contracts[0].StrikePrice, contracts[len(contracts)-1].StrikePrice
+	minStrike, maxStrike := contracts[0].ReferencePrice(), contracts[len(contracts)-1].ReferencePrice()
 	spread := maxStrike - minStrike // Calculate the spread between the max and min strike prices
 
 	// Calculate a buffer based on the maximum of delta and spread*beta
@@ -8,81 +8,15 @@ import (
 
 // CalculateBreakEvenPoints calculates the break-even points for a given set of options contracts
 func CalculateBreakEvenPoints(contracts []model.OptionsContract) (breakEvenPoints []float64) {
-	// Calculate the entry price
-	entryPrice := CalculateEntryPoint(contracts)
-
-	// Case 1: x <= min(strikes) Left Extremity
-	sumPut := 0.0
-	countPut := 0
-	// Iterate over all contracts to calculate sum and count for long and short puts
-	for _, contract := range contracts {
-		if contract.Type == model.Put && contract.LongShort == model.Long {
-			sumPut += contract.StrikePrice
-			countPut++
-		} else if contract.Type == model.Put && contract.LongShort == model.Short {
-			sumPut -= contract.StrikePrice
-			countPut--
-		}
-	}
-
-	// Calculate break-even point for the put contracts
-	if countPut != 0 {
-		breakPoint := (sumPut - entryPrice) / float64(countPut)
-		if breakPoint < contracts[0].StrikePrice {
-			breakEvenPoints = append(breakEvenPoints, roundNearestHundredth(breakPoint))
-		}
-	}
-
-	// Iterate over all contracts to find intermediate break-even points
-	for i := 1; i < len(contracts); i++ {
-		var x1, x2 float64
-		x1 = contracts[i-1].StrikePrice
-		x2 = contracts[i].StrikePrice
-
-		minProfitLoss := CalculateProfitLoss(x1, entryPrice, contracts)
-		maxProfitLoss := CalculateProfitLoss(x2, entryPrice, contracts)
-
-		// Check if either strike price is a break-even point
-		if minProfitLoss == 0 {
-			breakEvenPoints = append(breakEvenPoints, x1)
-		}
-		if maxProfitLoss == 0 {
-			breakEvenPoints = append(breakEvenPoints, x2)
-		}
-
-		// Check if there is a sign change between x1 and x2 indicating a break-even point
-		if minProfitLoss < 0 && 0 < maxProfitLoss || maxProfitLoss < 0 && 0 < minProfitLoss {
-			breakEvenPoints = append(breakEvenPoints, roundNearestHundredth(BisectionMethod(x1, x2, entryPrice, contracts)))
-		}
-	}
-
-	if len(contracts) == 1 {
-		profitLoss := CalculateProfitLoss(contracts[0].StrikePrice, entryPrice, contracts)
-		if profitLoss == 0 {
-			breakEvenPoints = append(breakEvenPoints, contracts[0].StrikePrice)
-		}
-	}
+	// The payoff is piecewise linear so every break-even point is the exact root of a segment
+	return breakEvenPointsFromProfile(BuildPayoffProfile(contracts))
+}
 
-	// Case 3: x > max(strikes)
-	sumCall := 0.0
-	countCall := 0
-	for _, contract := range contracts {
-		if contract.Type == model.Call && contract.LongShort == model.Long {
-			sumCall += contract.StrikePrice
-			countCall++
-		} else if contract.Type == model.Call && contract.LongShort == model.Short {
-			sumCall -= contract.StrikePrice
-			countCall--
-		}
-	}
-	if countCall != 0 {
-		breakPoint := (sumCall + entryPrice) / float64(countCall)
-		if breakPoint > contracts[len(contracts)-1].StrikePrice {
-			breakEvenPoints = append(breakEvenPoints, roundNearestHundredth(breakPoint))
-		}
+// breakEvenPointsFromProfile rounds the roots of a payoff profile to the nearest hundredth
+func breakEvenPointsFromProfile(profile Profile) (breakEvenPoints []float64) {
+	for _, root := range profile.Roots() {
+		breakEvenPoints = append(breakEvenPoints, roundNearestHundredth(root))
 	}
-
-	// Return the list of calculated break-even points
 	return breakEvenPoints
 }
 
@@ -90,20 +24,27 @@ func CalculateBreakEvenPoints(contracts []model.OptionsContract) (breakEvenPoint
 func BisectionMethod(a, b, entryPrice float64, contracts []model.OptionsContract) float64 {
 	const tolerance = 1e-3 // Define the tolerance for stopping the iteration (.001)
 
+	return bisect(func(price float64) float64 {
+		return CalculateProfitLoss(price, entryPrice, contracts)
+	}, a, b, tolerance)
+}
+
+// bisect uses the bisection method to find a root of a function within an interval [a, b]
+func bisect(f func(float64) float64, a, b, tolerance float64) float64 {
 	// Iterate until the interval is sufficiently small
 	for (b-a)/2 > tolerance {
 		midPoint := (a + b) / 2
-		profitLossMid := CalculateProfitLoss(midPoint, entryPrice, contracts)
+		valueMid := f(midPoint)
 
-		// If the profit/loss at midPoint is within the tolerance, return midPoint
-		if math.Abs(profitLossMid) <= tolerance {
+		// If the value at midPoint is within the tolerance, return midPoint
+		if math.Abs(valueMid) <= tolerance {
 			return midPoint
 		}
 
-		profitLossA := CalculateProfitLoss(a, entryPrice, contracts)
+		valueA := f(a)
 
 		// Determine which sub-interval contains the root
-		if (profitLossMid > 0 && profitLossA < 0) || (profitLossMid < 0 && profitLossA > 0) {
+		if (valueMid > 0 && valueA < 0) || (valueMid < 0 && valueA > 0) {
 			b = midPoint
 		} else {
 			a = midPoint
@@ -0,0 +1,44 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+// CalculateExerciseBoundaries calculates the early-exercise boundary of every american option in a set of contracts.
+// Options that are never worth exercising early, like calls without dividends, are left out.
+func CalculateExerciseBoundaries(contracts []model.OptionsContract, spot float64, market pricing.Market, at time.Time) []model.ExerciseBoundary {
+	var boundaries []model.ExerciseBoundary
+	for _, contract := range contracts {
+		if !contract.IsOption() || contract.ExerciseStyle != model.American {
+			continue
+		}
+		years := pricing.YearsToExpiry(contract, at)
+
+		// Without a spot the tree is centered on the strike
+		center := spot
+		if center <= 0 {
+			center = contract.StrikePrice
+		}
+		dividends := pricing.ScheduleDividends(market.Dividends, at, contract.ExpiresAt())
+		points := pricing.EarlyExerciseBoundary(contract.Type, center, contract.StrikePrice, years, market.RiskFreeRate, market.DividendYield, market.LegVolatility(contract, at), dividends)
+		if len(points) == 0 {
+			continue
+		}
+
+		boundary := model.ExerciseBoundary{
+			Type:           contract.Type,
+			LongShort:      contract.LongShort,
+			StrikePrice:    contract.StrikePrice,
+			ExpirationDate: contract.ExpirationDate,
+		}
+		for _, point := range points {
+			date := pricing.DateAfter(at, point.Years)
+			boundary.Boundary = append(boundary.Boundary, model.ExerciseBoundaryPoint{Date: date, UnderlyingPrice: roundNearestHundredth(point.UnderlyingPrice)})
+		}
+		boundaries = append(boundaries, boundary)
+	}
+	return boundaries
+}
@@ -0,0 +1,34 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/calendar"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+// CalculateExpirations calculates the instant every option settles at and the trading days left until then from the valuation time
+func CalculateExpirations(contracts []model.OptionsContract, at time.Time) []model.LegExpiration {
+	var expirations []model.LegExpiration
+	for _, contract := range contracts {
+		// Only options expire
+		if !contract.IsOption() {
+			continue
+		}
+		settlement := contract.Settlement
+		if settlement == "" {
+			settlement = calendar.PM
+		}
+		expiresAt := contract.ExpiresAt()
+		expirations = append(expirations, model.LegExpiration{
+			Type:        contract.Type,
+			LongShort:   contract.LongShort,
+			StrikePrice: contract.StrikePrice,
+			Symbol:      contract.Symbol,
+			Settlement:  settlement,
+			ExpiresAt:   expiresAt,
+			TradingDays: calendar.TradingDaysToExpiry(at, expiresAt),
+		})
+	}
+	return expirations
+}
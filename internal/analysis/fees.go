@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+// CalculateEntryFees calculates the commissions charged to open a set of contracts
+func CalculateEntryFees(contracts []model.OptionsContract, fees model.FeeSchedule) float64 {
+	total := fees.PerOrder
+	for _, contract := range contracts {
+		// Stock is not traded by the contract so it only pays the leg minimum
+		commission := 0.0
+		if contract.Type != model.Stock {
+			commission = fees.PerContract * float64(contract.ContractQuantity())
+		}
+		total += math.Max(commission, fees.PerLegMinimum)
+	}
+	return roundNearestHundredth(total)
+}
+
+// CalculateSettlementFees calculates the exercise and assignment fees charged at expiry for the options finishing in the money
+func CalculateSettlementFees(contracts []model.OptionsContract, fees model.FeeSchedule, price float64) float64 {
+	total := 0.0
+	for _, contract := range contracts {
+		inTheMoney := (contract.Type == model.Call && price > contract.StrikePrice) ||
+			(contract.Type == model.Put && price < contract.StrikePrice)
+		if !inTheMoney {
+			continue
+		}
+
+		// Long contracts are exercised and short contracts are assigned
+		fee := fees.Exercise
+		if contract.LongShort == model.Short {
+			fee = fees.Assignment
+		}
+		total += fee * float64(contract.ContractQuantity())
+	}
+	return total
+}
+
+// CalculateNetProfit calculates the total profit for a set of options contracts at a given price after all fees
+func CalculateNetProfit(contracts []model.OptionsContract, fees model.FeeSchedule, price float64) float64 {
+	return CalculateTotalProfit(contracts, price) - CalculateEntryFees(contracts, fees) - CalculateSettlementFees(contracts, fees, price)
+}
+
+// CalculateFeeAnalysis calculates the fees of a set of contracts and compares their results before and after them
+func CalculateFeeAnalysis(contracts []model.OptionsContract, fees model.FeeSchedule) model.FeeAnalysis {
+	return feeAnalysisFromProfiles(contracts, fees, BuildPayoffProfile(contracts), BuildNetPayoffProfile(contracts, fees))
+}
+
+// CalculateFeeAnalysisAtDate calculates the fees of a set of contracts and compares their results at the evaluation date before and after them
+func CalculateFeeAnalysisAtDate(contracts []model.OptionsContract, fees model.FeeSchedule, market pricing.Market, at time.Time) model.FeeAnalysis {
+	gross := BuildModelProfile(contracts, model.FeeSchedule{}, market, at)
+	net := BuildModelProfile(contracts, fees, market, at)
+	return feeAnalysisFromProfiles(contracts, fees, gross, net)
+}
+
+// feeAnalysisFromProfiles compares the results of the gross and net profiles of a set of contracts
+func feeAnalysisFromProfiles(contracts []model.OptionsContract, fees model.FeeSchedule, gross, net Profile) model.FeeAnalysis {
+	grossMaxProfit, grossMaxLoss, _, _ := gross.Extremes()
+	netMaxProfit, netMaxLoss, _, _ := net.Extremes()
+
+	return model.FeeAnalysis{
+		EntryFees:            CalculateEntryFees(contracts, fees),
+		GrossMaxProfit:       formatProfitLoss(grossMaxProfit),
+		GrossMaxLoss:         formatProfitLoss(grossMaxLoss),
+		GrossBreakEvenPoints: breakEvenPointsFromProfile(gross),
+		NetMaxProfit:         formatProfitLoss(netMaxProfit),
+		NetMaxLoss:           formatProfitLoss(netMaxLoss),
+		NetBreakEvenPoints:   breakEvenPointsFromProfile(net),
+	}
+}
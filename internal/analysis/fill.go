@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+// CalculateFillPrice calculates the per-unit price a contract is opened at.
+// An explicit fill price always wins, otherwise the option is priced from its quotes by the fill mode.
+func CalculateFillPrice(contract model.OptionsContract, mode model.FillMode) float64 {
+	if contract.FillPrice != nil {
+		return *contract.FillPrice
+	}
+	// The underlying is opened at its cost basis
+	if !contract.IsOption() {
+		return contract.CostBasis
+	}
+
+	switch mode {
+	case model.Mid:
+		return (contract.Bid + contract.Ask) / 2
+	case model.Worst:
+		if contract.LongShort == model.Long {
+			return math.Max(contract.Bid, contract.Ask)
+		}
+		return math.Min(contract.Bid, contract.Ask)
+	default:
+		if contract.LongShort == model.Long {
+			return contract.Ask
+		}
+		return contract.Bid
+	}
+}
+
+// ApplyFillMode sets the fill price of every option without an explicit one so every calculation prices it the same way
+func ApplyFillMode(contracts []model.OptionsContract, mode model.FillMode) {
+	for i := range contracts {
+		if contracts[i].IsOption() && contracts[i].FillPrice == nil {
+			fillPrice := CalculateFillPrice(contracts[i], mode)
+			contracts[i].FillPrice = &fillPrice
+		}
+	}
+}
+
+// DeterminePremiumType determines whether a net premium is paid or received
+func DeterminePremiumType(netPremium float64) model.PremiumType {
+	switch {
+	case netPremium > 0:
+		return model.Debit
+	case netPremium < 0:
+		return model.Credit
+	default:
+		return model.Even
+	}
+}
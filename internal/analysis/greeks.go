@@ -0,0 +1,27 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+// CalculatePositionGreeks calculates the greeks of every contract and of the whole position.
+// The greeks are signed by the position and scaled by the units of the underlying so the legs add up to the total.
+func CalculatePositionGreeks(contracts []model.OptionsContract, spot float64, market pricing.Market, at time.Time) model.PositionGreeks {
+	var positionGreeks model.PositionGreeks
+	for _, contract := range contracts {
+		greeks := pricing.ContractGreeks(contract, spot, market, at).Scale(PositionSize(contract))
+
+		positionGreeks.Legs = append(positionGreeks.Legs, model.LegGreeks{
+			Type:        contract.Type,
+			LongShort:   contract.LongShort,
+			StrikePrice: contract.StrikePrice,
+			Symbol:      contract.Symbol,
+			Greeks:      greeks,
+		})
+		positionGreeks.Total = positionGreeks.Total.Add(greeks)
+	}
+	return positionGreeks
+}
@@ -0,0 +1,37 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+// CalculateImpliedVolatilities calculates the implied volatility of the bid, mid and ask of every contract
+func CalculateImpliedVolatilities(contracts []model.OptionsContract, spot float64, carry model.Carry, at time.Time) []model.LegImpliedVolatility {
+	var volatilities []model.LegImpliedVolatility
+	for _, contract := range contracts {
+		// Only options have a volatility to back out
+		if !contract.IsOption() {
+			continue
+		}
+		solve := func(premium float64) *float64 {
+			volatility, err := pricing.ContractImpliedVolatility(contract, premium, spot, carry, at)
+			if err != nil {
+				return nil
+			}
+			return &volatility
+		}
+
+		volatilities = append(volatilities, model.LegImpliedVolatility{
+			Type:        contract.Type,
+			LongShort:   contract.LongShort,
+			StrikePrice: contract.StrikePrice,
+			Symbol:      contract.Symbol,
+			Bid:         solve(contract.Bid),
+			Mid:         solve((contract.Bid + contract.Ask) / 2),
+			Ask:         solve(contract.Ask),
+		})
+	}
+	return volatilities
+}
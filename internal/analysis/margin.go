@@ -0,0 +1,199 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+const (
+	NAKED_MARGIN_RATE      = 0.20 // Share of the underlying price a naked option requires, less the amount it is out of the money
+	MINIMUM_MARGIN_RATE    = 0.10 // Share of the underlying price, or of the strike of a put, a naked option requires at least
+	UNDERLYING_MARGIN_RATE = 0.50 // Reg-T initial requirement of shares bought or sold short
+)
+
+// marginLeg tracks the units of a short option that are still to be covered
+type marginLeg struct {
+	contract model.OptionsContract
+	units    float64
+}
+
+// marginSide sums the requirement of the short options of one type, along with the premium of the naked ones
+type marginSide struct {
+	requirement  float64
+	nakedPremium float64
+}
+
+// CalculateMargin calculates the Reg-T strategy-based requirement of a set of contracts and the buying power it uses.
+// Short options are first covered by the underlying, then paired into spreads with long options of the same type expiring no earlier,
+// and what is left is naked. The naked requirement is measured against the spot, or against the strike when there is none.
+func CalculateMargin(contracts []model.OptionsContract, spot, maxProfit float64) model.MarginAnalysis {
+	var margin model.MarginAnalysis
+	var shortCalls, shortPuts, longCalls, longPuts []*marginLeg
+	var longShares, shortShares, underlyingRequirement, longCost, shortProceeds float64
+	for _, contract := range contracts {
+		units := contract.Units()
+		switch {
+		case contract.Type == model.Future:
+			margin.Components = append(margin.Components, marginComponent(contract, units, model.MarginFutures, 0))
+		case contract.Type == model.Stock:
+			requirement := UNDERLYING_MARGIN_RATE * contract.CostBasis * units
+			underlyingRequirement += requirement
+			margin.Components = append(margin.Components, marginComponent(contract, units, model.MarginUnderlying, requirement))
+			if contract.LongShort == model.Long {
+				longShares += units
+			} else {
+				shortShares += units
+			}
+		case contract.LongShort == model.Long:
+			// Long options are paid for in full
+			cost := CalculateFillPrice(contract, model.Natural) * units
+			longCost += cost
+			margin.Components = append(margin.Components, marginComponent(contract, units, model.MarginLong, cost))
+			if contract.Type == model.Call {
+				longCalls = append(longCalls, &marginLeg{contract, units})
+			} else {
+				longPuts = append(longPuts, &marginLeg{contract, units})
+			}
+		default:
+			shortProceeds += CalculateFillPrice(contract, model.Natural) * units
+			if contract.Type == model.Call {
+				shortCalls = append(shortCalls, &marginLeg{contract, units})
+			} else {
+				shortPuts = append(shortPuts, &marginLeg{contract, units})
+			}
+		}
+	}
+
+	// Cover the riskiest short options first, the lowest calls and the highest puts
+	sortMarginLegs(shortCalls, false)
+	sortMarginLegs(shortPuts, true)
+	// Long shares cover short calls and short shares cover short puts
+	margin.Components = append(margin.Components, coverWithUnderlying(shortCalls, longShares)...)
+	margin.Components = append(margin.Components, coverWithUnderlying(shortPuts, shortShares)...)
+
+	// Pair with the long options that protect the most, the lowest calls and the highest puts
+	sortMarginLegs(longCalls, false)
+	sortMarginLegs(longPuts, true)
+	calls, callComponents := marginShortOptions(shortCalls, longCalls, spot)
+	puts, putComponents := marginShortOptions(shortPuts, longPuts, spot)
+	margin.Components = append(margin.Components, callComponents...)
+	margin.Components = append(margin.Components, putComponents...)
+
+	// Short calls and puts cannot both finish in the money when every put strike is below every call strike,
+	// so only the riskier side is required, plus the premium of the naked options on the other side
+	optionRequirement := calls.requirement + puts.requirement
+	if exclusiveSides(shortCalls, shortPuts) {
+		optionRequirement = math.Max(calls.requirement+puts.nakedPremium, puts.requirement+calls.nakedPremium)
+	}
+
+	requirement := optionRequirement + underlyingRequirement + longCost
+	margin.Requirement = roundNearestHundredth(requirement)
+	margin.BuyingPowerEffect = roundNearestHundredth(math.Max(0, requirement-shortProceeds))
+	if margin.BuyingPowerEffect > 0 && maxProfit > 0 && !math.IsInf(maxProfit, 1) {
+		margin.ReturnOnCapital = ratio(maxProfit / margin.BuyingPowerEffect)
+	}
+	return margin
+}
+
+// coverWithUnderlying covers the short options with the given units of the underlying, which need no further requirement
+func coverWithUnderlying(shorts []*marginLeg, units float64) []model.MarginComponent {
+	var components []model.MarginComponent
+	for _, short := range shorts {
+		covered := math.Min(short.units, units)
+		if covered <= 0 {
+			continue
+		}
+		short.units -= covered
+		units -= covered
+		components = append(components, marginComponent(short.contract, covered, model.MarginCovered, 0))
+	}
+	return components
+}
+
+// marginShortOptions pairs the uncovered units of short options of one type with the long options into spreads, requiring the amount
+// the spread can lose beyond the premium, and requires the rest as naked options
+func marginShortOptions(shorts, longs []*marginLeg, spot float64) (marginSide, []model.MarginComponent) {
+	var side marginSide
+	var components []model.MarginComponent
+	for _, short := range shorts {
+		for _, long := range longs {
+			if short.units <= 0 {
+				break
+			}
+			paired := math.Min(short.units, long.units)
+			if paired <= 0 || long.contract.ExpiresAt().Before(short.contract.ExpiresAt()) {
+				continue
+			}
+			short.units -= paired
+			long.units -= paired
+
+			// The spread loses the distance between the strikes when the short option is the one nearer the money
+			width := long.contract.StrikePrice - short.contract.StrikePrice
+			if short.contract.Type == model.Put {
+				width = -width
+			}
+			requirement := math.Max(0, width) * paired
+			side.requirement += requirement
+			components = append(components, marginComponent(short.contract, paired, model.MarginSpread, requirement))
+		}
+
+		if short.units > 0 {
+			premium := CalculateFillPrice(short.contract, model.Natural) * short.units
+			requirement := premium + NakedOptionMargin(short.contract, spot)*short.units
+			side.requirement += requirement
+			side.nakedPremium += premium
+			components = append(components, marginComponent(short.contract, short.units, model.MarginNaked, requirement))
+			short.units = 0
+		}
+	}
+	return side, components
+}
+
+// NakedOptionMargin calculates the per-unit Reg-T requirement of a naked option on top of its premium,
+// a share of the underlying price less the amount it is out of the money, with a floor
+func NakedOptionMargin(contract model.OptionsContract, spot float64) float64 {
+	// Without a spot the option is taken at the money
+	if spot <= 0 {
+		spot = contract.StrikePrice
+	}
+	if contract.Type == model.Put {
+		outOfTheMoney := math.Max(0, spot-contract.StrikePrice)
+		return math.Max(NAKED_MARGIN_RATE*spot-outOfTheMoney, MINIMUM_MARGIN_RATE*contract.StrikePrice)
+	}
+	outOfTheMoney := math.Max(0, contract.StrikePrice-spot)
+	return math.Max(NAKED_MARGIN_RATE*spot-outOfTheMoney, MINIMUM_MARGIN_RATE*spot)
+}
+
+// exclusiveSides returns whether every short put strike is at or below every short call strike, when there are both
+func exclusiveSides(shortCalls, shortPuts []*marginLeg) bool {
+	if len(shortCalls) == 0 || len(shortPuts) == 0 {
+		return false
+	}
+	// The calls are sorted up and the puts down so the first of each are the nearest
+	return shortPuts[0].contract.StrikePrice <= shortCalls[0].contract.StrikePrice
+}
+
+// sortMarginLegs sorts the legs by strike price, up or down
+func sortMarginLegs(legs []*marginLeg, descending bool) {
+	sort.SliceStable(legs, func(i, j int) bool {
+		if descending {
+			return legs[i].contract.StrikePrice > legs[j].contract.StrikePrice
+		}
+		return legs[i].contract.StrikePrice < legs[j].contract.StrikePrice
+	})
+}
+
+// marginComponent builds the margin component of some units of a contract
+func marginComponent(contract model.OptionsContract, units float64, treatment model.MarginTreatment, requirement float64) model.MarginComponent {
+	return model.MarginComponent{
+		Type:        contract.Type,
+		LongShort:   contract.LongShort,
+		StrikePrice: contract.StrikePrice,
+		Symbol:      contract.Symbol,
+		Units:       units,
+		Treatment:   treatment,
+		Requirement: roundNearestHundredth(requirement),
+	}
+}
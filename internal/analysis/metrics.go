@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+// CalculateRiskMetrics calculates the ratios of the maximum profit and maximum loss of a set of contracts, and of them to the width of its spreads
+func CalculateRiskMetrics(contracts []model.OptionsContract, maxProfit, maxLoss, netPremium float64) model.RiskMetrics {
+	metrics := model.RiskMetrics{UnboundedProfit: math.IsInf(maxProfit, 1), UnboundedLoss: math.IsInf(maxLoss, -1)}
+	risk := -maxLoss
+
+	// A bounded extreme over an unbounded one is 0, an unbounded one over a bounded one has no value, and neither has a position that cant lose
+	switch {
+	case metrics.UnboundedLoss || maxProfit <= 0 || risk < 0:
+	case metrics.UnboundedProfit:
+		metrics.RiskRewardRatio = ratio(0)
+	default:
+		metrics.RiskRewardRatio = ratio(risk / maxProfit)
+	}
+	switch {
+	case metrics.UnboundedProfit || risk <= 0:
+	case metrics.UnboundedLoss:
+		metrics.ReturnOnMaxRisk = ratio(0)
+	default:
+		metrics.ReturnOnMaxRisk = ratio(maxProfit / risk)
+	}
+
+	// The width only means something for a spread, measured in the units of its short option
+	width, units, ok := SpreadWidth(contracts)
+	if !ok {
+		return metrics
+	}
+	metrics.Width = ratio(width)
+	if !metrics.UnboundedProfit {
+		metrics.MaxProfitPercentOfWidth = ratio(maxProfit / (width * units) * 100)
+	}
+	if netPremium < 0 {
+		metrics.CreditPercentOfWidth = ratio(-netPremium / (width * units) * 100)
+	}
+	return metrics
+}
+
+// SpreadWidth calculates the widest distance between a short option and the nearest long option of the same type, along with the units they share.
+// It reports false when no short option is paired with a long option at another strike.
+func SpreadWidth(contracts []model.OptionsContract) (width, units float64, ok bool) {
+	for _, short := range contracts {
+		if !short.IsOption() || short.LongShort != model.Short {
+			continue
+		}
+
+		// Find the nearest long option protecting the short one
+		nearest, pairedUnits := math.Inf(1), 0.0
+		for _, long := range contracts {
+			if long.Type != short.Type || long.LongShort != model.Long {
+				continue
+			}
+			if distance := math.Abs(long.StrikePrice - short.StrikePrice); distance < nearest {
+				nearest, pairedUnits = distance, math.Min(short.Units(), long.Units())
+			}
+		}
+		if !math.IsInf(nearest, 1) && nearest > width {
+			width, units, ok = nearest, pairedUnits, true
+		}
+	}
+	return width, units, ok
+}
+
+// ratio returns a pointer to the value so it can be left out
+func ratio(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,202 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+const MODEL_PROFILE_STEPS = 600 // Number of even samples of a model profile
+
+// Profile represents the profit/loss of a position across the underlying price
+type Profile interface {
+	Extremes() (maxProfit, maxLoss float64, maxProfitPrices, maxLossPrices []float64)
+	Roots() []float64
+	Piecewise() PayoffProfile
+}
+
+// ModelProfile represents the profit/loss of a position at a date when some of its contracts are still alive.
+// The model value is not piecewise linear so the profile is sampled densely and refined numerically.
+type ModelProfile struct {
+	Samples    []model.RiskRewardGraph // The profit/loss sampled from zero to well past the highest strike
+	RightSlope float64                 // The profit/loss change per unit of price far above the highest strike
+	profitLoss func(float64) float64
+}
+
+// BuildModelProfile builds the profile of a set of contracts net of the fees at the evaluation date,
+// valuing the contracts still alive with Black-Scholes and the expired ones at their intrinsic value
+func BuildModelProfile(contracts []model.OptionsContract, fees model.FeeSchedule, market pricing.Market, at time.Time) ModelProfile {
+	entryPrice := CalculateEntryPoint(contracts) + CalculateEntryFees(contracts, fees)
+	expired := ExpiredContracts(contracts, at)
+	return buildModelProfile(distinctReferencePrices(contracts), func(price float64) float64 {
+		return CalculateTheoreticalValue(contracts, price, market, at) - entryPrice - CalculateSettlementFees(expired, fees, price)
+	})
+}
+
+// buildModelProfile samples a profit/loss function from zero to three times the highest reference price
+func buildModelProfile(referencePrices []float64, profitLoss func(float64) float64) ModelProfile {
+	maxPrice := 3 * referencePrices[len(referencePrices)-1]
+	prices := append([]float64{}, referencePrices...)
+	for i := 0; i <= MODEL_PROFILE_STEPS; i++ {
+		prices = append(prices, maxPrice*float64(i)/MODEL_PROFILE_STEPS)
+	}
+	sort.Float64s(prices)
+
+	profile := ModelProfile{profitLoss: profitLoss}
+	for i, price := range prices {
+		// Skip the reference prices that fall on a step
+		if i > 0 && price-prices[i-1] <= vertexTolerance {
+			continue
+		}
+		profile.Samples = append(profile.Samples, model.RiskRewardGraph{UnderlyingPrice: price, ProfitLoss: profitLoss(price)})
+	}
+
+	// Far above the strikes every option is deep in or out of the money so the profit/loss is a straight line
+	far := 10 * maxPrice
+	profile.RightSlope = (profitLoss(2*far) - profitLoss(far)) / far
+	return profile
+}
+
+// ProfitLoss calculates the profit/loss of the profile at a given price
+func (p ModelProfile) ProfitLoss(price float64) float64 {
+	return p.profitLoss(price)
+}
+
+// Extremes calculates the maximum profit and maximum loss of the profile and the prices where they occur.
+// No prices are returned for an unbounded extreme.
+func (p ModelProfile) Extremes() (maxProfit, maxLoss float64, maxProfitPrices, maxLossPrices []float64) {
+	best, worst := 0, 0
+	for i, sample := range p.Samples {
+		if sample.ProfitLoss > p.Samples[best].ProfitLoss {
+			best = i
+		}
+		if sample.ProfitLoss < p.Samples[worst].ProfitLoss {
+			worst = i
+		}
+	}
+
+	maxProfitPrice, maxProfit := p.refine(best, 1)
+	maxLossPrice, maxLoss := p.refine(worst, -1)
+	maxProfitPrices = []float64{roundNearestHundredth(maxProfitPrice)}
+	maxLossPrices = []float64{roundNearestHundredth(maxLossPrice)}
+
+	// A rising line keeps increasing the profit and a falling line keeps increasing the loss, the price cant go below zero
+	if p.RightSlope > limitTolerance {
+		maxProfit, maxProfitPrices = math.Inf(1), nil
+	}
+	if p.RightSlope < -limitTolerance {
+		maxLoss, maxLossPrices = math.Inf(-1), nil
+	}
+	return maxProfit, maxLoss, maxProfitPrices, maxLossPrices
+}
+
+// Roots calculates every price at which the profile crosses or touches zero
+func (p ModelProfile) Roots() (roots []float64) {
+	const tolerance = 1e-6 // Define the tolerance of the root search
+
+	for i, sample := range p.Samples {
+		if math.Abs(sample.ProfitLoss) <= vertexTolerance {
+			roots = append(roots, sample.UnderlyingPrice)
+			continue
+		}
+		if i+1 < len(p.Samples) && changesSign(sample.ProfitLoss, p.Samples[i+1].ProfitLoss) {
+			roots = append(roots, bisect(p.profitLoss, sample.UnderlyingPrice, p.Samples[i+1].UnderlyingPrice, tolerance))
+		}
+	}
+
+	// The straight line past the last sample can still cross zero
+	last := p.Samples[len(p.Samples)-1]
+	if math.Abs(p.RightSlope) > limitTolerance {
+		if root := last.UnderlyingPrice - last.ProfitLoss/p.RightSlope; root > last.UnderlyingPrice+vertexTolerance {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// Piecewise approximates the profile with straight lines between its samples
+func (p ModelProfile) Piecewise() PayoffProfile {
+	piecewise := PayoffProfile{RightSlope: p.RightSlope}
+	for _, sample := range p.Samples {
+		piecewise.Vertices = append(piecewise.Vertices, PayoffVertex{
+			UnderlyingPrice: sample.UnderlyingPrice,
+			ProfitLoss:      sample.ProfitLoss,
+			LeftLimit:       sample.ProfitLoss,
+			RightLimit:      sample.ProfitLoss,
+		})
+	}
+	return piecewise
+}
+
+// refine refines a sampled extreme with a golden-section search between its neighbouring samples, the sign is 1 for a maximum and -1 for a minimum
+func (p ModelProfile) refine(index int, sign float64) (float64, float64) {
+	const tolerance = 1e-6 // Define the tolerance of the search interval
+	ratio := (math.Sqrt(5) - 1) / 2
+
+	a := p.Samples[max(0, index-1)].UnderlyingPrice
+	b := p.Samples[min(len(p.Samples)-1, index+1)].UnderlyingPrice
+	for b-a > tolerance {
+		c, d := b-ratio*(b-a), a+ratio*(b-a)
+		if sign*p.profitLoss(c) > sign*p.profitLoss(d) {
+			b = d
+		} else {
+			a = c
+		}
+	}
+
+	// Keep the sample when the search does not improve on it, as on a flat stretch or at a kink
+	price := (a + b) / 2
+	if sign*p.profitLoss(price) <= sign*p.Samples[index].ProfitLoss {
+		return p.Samples[index].UnderlyingPrice, p.Samples[index].ProfitLoss
+	}
+	return price, p.profitLoss(price)
+}
+
+// ExpiredContracts returns the contracts that have expired by the given date
+func ExpiredContracts(contracts []model.OptionsContract, at time.Time) []model.OptionsContract {
+	var expired []model.OptionsContract
+	for _, contract := range contracts {
+		if contract.IsOption() && !contract.ExpiresAt().After(at) {
+			expired = append(expired, contract)
+		}
+	}
+	return expired
+}
+
+// DetermineEvaluationDate determines the date contracts expiring on different dates are evaluated at,
+// the requested date or otherwise the nearest expiration. It reports false when the contracts are evaluated at expiry,
+// as they are without a volatility to value them at the nearest expiration.
+func DetermineEvaluationDate(request model.AnalysisRequest) (time.Time, bool) {
+	if request.EvaluationDate != nil {
+		return *request.EvaluationDate, true
+	}
+	if !model.HasMixedExpirations(request.Contracts) || !request.HasVolatility() {
+		return time.Time{}, false
+	}
+	return NearestExpiration(request.Contracts)
+}
+
+// NearestExpiration returns the first instant the options in a set of contracts settle at, it reports false when there are no options
+func NearestExpiration(contracts []model.OptionsContract) (time.Time, bool) {
+	var nearest time.Time
+	for _, contract := range contracts {
+		if contract.IsOption() && (nearest.IsZero() || contract.ExpiresAt().Before(nearest)) {
+			nearest = contract.ExpiresAt()
+		}
+	}
+	return nearest, !nearest.IsZero()
+}
+
+// FinalExpiration returns the last instant the options in a set of contracts settle at, it reports false when there are no options
+func FinalExpiration(contracts []model.OptionsContract) (time.Time, bool) {
+	var final time.Time
+	for _, contract := range contracts {
+		if contract.IsOption() && contract.ExpiresAt().After(final) {
+			final = contract.ExpiresAt()
+		}
+	}
+	return final, !final.IsZero()
+}
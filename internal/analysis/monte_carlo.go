@@ -0,0 +1,197 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+const SIMULATION_GRID_PRICES = 200 // Number of prices the value of the position is tabulated at for every step
+
+// simulationPercentiles are the percentiles of the simulated profit/loss reported
+var simulationPercentiles = []int{5, 25, 50, 75, 95}
+
+// SimulateContracts simulates paths of the underlying from the spot until the horizon with a geometric Brownian motion, with optional jumps,
+// and closes the position along every path once it reaches the profit target or the stop loss.
+// The motion drives the underlying net of the dividends paid until the horizon, which are added back at every step.
+func SimulateContracts(contracts []model.OptionsContract, fees model.FeeSchedule, profile Profile, settings model.SimulationSettings,
+	spot float64, market pricing.Market, now, horizon time.Time) model.SimulationAnalysis {
+	years := pricing.YearsBetween(now, horizon)
+	volatility := market.UnderlyingVolatility(spot, horizon, now)
+	dividends := pricing.ScheduleDividends(market.Dividends, now, horizon)
+
+	// Fill in the defaults, a step per session as far as the budget allows and a random seed that is reported back so the run can be repeated.
+	// The seed comes off the system clock, the valuation time repeats whenever the valuation date is pinned
+	result := model.SimulationAnalysis{Paths: settings.Paths, Steps: settings.Steps, Seed: time.Now().UnixNano()}
+	if result.Paths == 0 {
+		result.Paths = model.DEFAULT_SIMULATION_PATHS
+	}
+	if result.Steps == 0 {
+		result.Steps = max(1, min(int(math.Ceil(years*pricing.TRADING_DAYS_PER_YEAR)), settings.MaxSteps(contracts)))
+	}
+	if settings.Seed != nil {
+		result.Seed = *settings.Seed
+	}
+	random := rand.New(rand.NewSource(result.Seed))
+
+	// A target is only reachable on a bounded extreme of the right sign
+	maxProfit, maxLoss, _, _ := profile.Extremes()
+	target, stop := math.Inf(1), math.Inf(-1)
+	if settings.ProfitTarget > 0 && maxProfit > 0 && !math.IsInf(maxProfit, 1) {
+		target = settings.ProfitTarget * maxProfit
+	}
+	if settings.StopLoss > 0 && maxLoss < 0 && !math.IsInf(maxLoss, -1) {
+		stop = settings.StopLoss * maxLoss
+	}
+
+	// Value the position at every step, the contracts that have expired by then settle at their intrinsic value
+	entryPrice := CalculateEntryPoint(contracts) + CalculateEntryFees(contracts, fees)
+	dt := years / float64(result.Steps)
+	stepDate := func(step int) time.Time {
+		if step == result.Steps {
+			return horizon
+		}
+		return pricing.DateAfter(now, float64(step)*dt)
+	}
+
+	// Only the price changes from one path to the next, so the valuation of every contract and the contracts expired by then
+	// are worked out once for every step
+	expiries := make([]time.Time, len(contracts))
+	for i, contract := range contracts {
+		expiries[i] = contract.ExpiresAt()
+	}
+	valuations := make([][]pricing.Valuation, result.Steps+1)
+	expired := make([][]model.OptionsContract, result.Steps+1)
+	for step := range valuations {
+		at := stepDate(step)
+		for i, contract := range contracts {
+			valuations[step] = append(valuations[step], pricing.NewValuation(contract, market, at))
+			if contract.IsOption() && !expiries[i].After(at) {
+				expired[step] = append(expired[step], contract)
+			}
+		}
+	}
+	value := func(price float64, step int) float64 {
+		total := 0.0
+		for i, valuation := range valuations[step] {
+			total += valuation.Value(price) * PositionSize(contracts[i])
+		}
+		return total
+	}
+
+	// American contracts are valued on a tree, too slow to run at every step of every path, so the value is interpolated from a grid of prices
+	// spanning six standard deviations of the underlying either side of the spot
+	if slices.ContainsFunc(contracts, func(contract model.OptionsContract) bool { return contract.ExerciseStyle == model.American }) {
+		// Without any volatility there is no spread to tabulate and every path follows the forward
+		variance := (volatility*volatility + settings.JumpIntensity*(settings.JumpMean*settings.JumpMean+settings.JumpVolatility*settings.JumpVolatility)) * years
+		if variance > 0 {
+			value = gridValues(value, spot, 6*math.Sqrt(variance), result.Steps)
+		}
+	}
+	profitLoss := func(price float64, step int) float64 {
+		return value(price, step) - entryPrice - CalculateSettlementFees(expired[step], fees, price)
+	}
+
+	// The drift is compensated for the jumps so the underlying still grows at the risk-free rate net of the dividend yield
+	jumpCompensation := settings.JumpIntensity * (math.Exp(settings.JumpMean+settings.JumpVolatility*settings.JumpVolatility/2) - 1)
+	drift := (market.RiskFreeRate - market.DividendYield - volatility*volatility/2 - jumpCompensation) * dt
+	diffusion := volatility * math.Sqrt(dt)
+
+	breakEvenPoints := breakEvenPointsFromProfile(profile)
+	touches := make([]int, len(breakEvenPoints))
+	var targetHits, stopHits int
+	outcomes := make([]float64, result.Paths)
+	escrowed := pricing.EscrowedSpot(spot, dividends, market.RiskFreeRate)
+	pending := make([]float64, result.Steps+1)
+	for step := range pending {
+		pending[step] = pricing.DividendsPresentValue(dividends, float64(step)*dt, market.RiskFreeRate)
+	}
+	for path := range outcomes {
+		net, low, high := escrowed, spot, spot
+		closed := false
+		for step := 1; step <= result.Steps; step++ {
+			logReturn := drift + diffusion*random.NormFloat64()
+			for jumps := poisson(random, settings.JumpIntensity*dt); jumps > 0; jumps-- {
+				logReturn += settings.JumpMean + settings.JumpVolatility*random.NormFloat64()
+			}
+			net *= math.Exp(logReturn)
+			price := net + pending[step]
+			low, high = math.Min(low, price), math.Max(high, price)
+
+			// Once closed the path only keeps going to track the break-even touches
+			if closed {
+				continue
+			}
+			outcomes[path] = profitLoss(price, step)
+			if outcomes[path] >= target {
+				targetHits++
+				closed = true
+			} else if outcomes[path] <= stop {
+				stopHits++
+				closed = true
+			}
+		}
+
+		for i, breakEven := range breakEvenPoints {
+			if low <= breakEven && breakEven <= high {
+				touches[i]++
+			}
+		}
+	}
+
+	paths := float64(result.Paths)
+	for i, breakEven := range breakEvenPoints {
+		result.BreakEvenTouches = append(result.BreakEvenTouches, model.BreakEvenTouch{BreakEvenPoint: breakEven, Probability: float64(touches[i]) / paths})
+	}
+	result.ProbabilityOfProfitTarget = float64(targetHits) / paths
+	result.ProbabilityOfStopLoss = float64(stopHits) / paths
+
+	// Nearest-rank percentiles of the profit/loss the position is closed at
+	sort.Float64s(outcomes)
+	for _, percentile := range simulationPercentiles {
+		index := max(0, int(math.Ceil(float64(percentile)/100*paths))-1)
+		result.Percentiles = append(result.Percentiles, model.ProfitLossPercentile{Percentile: percentile, ProfitLoss: roundNearestHundredth(outcomes[index])})
+	}
+	return result
+}
+
+// gridValues tabulates a value function for every step on prices evenly spaced in log around the spot and interpolates between them.
+// The grid of a step is only filled the first time it is used and prices outside of the grid fall back on the function.
+func gridValues(value func(float64, int) float64, spot, width float64, steps int) func(float64, int) float64 {
+	low := math.Log(spot) - width
+	spacing := 2 * width / (SIMULATION_GRID_PRICES - 1)
+	grid := make([][]float64, steps+1)
+	return func(price float64, step int) float64 {
+		position := (math.Log(price) - low) / spacing
+		index := int(position)
+		if spacing <= 0 || position < 0 || index >= SIMULATION_GRID_PRICES-1 {
+			return value(price, step)
+		}
+		if grid[step] == nil {
+			grid[step] = make([]float64, SIMULATION_GRID_PRICES)
+			for i := range grid[step] {
+				grid[step][i] = value(math.Exp(low+float64(i)*spacing), step)
+			}
+		}
+		weight := position - float64(index)
+		return grid[step][index]*(1-weight) + grid[step][index+1]*weight
+	}
+}
+
+// poisson draws the number of events of a Poisson process with the given mean
+func poisson(random *rand.Rand, mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+	limit, product, count := math.Exp(-mean), random.Float64(), 0
+	for product > limit {
+		product *= random.Float64()
+		count++
+	}
+	return count
+}
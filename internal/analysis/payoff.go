@@ -0,0 +1,190 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+const (
+	vertexTolerance = 1e-9 // Tolerance used when comparing vertex prices and profits
+	limitTolerance  = 1e-6 // Tolerance below which a limit only differs from its vertex by rounding
+)
+
+// PayoffProfile represents the exact piecewise-linear profit/loss of a position at expiry
+type PayoffProfile struct {
+	Vertices   []PayoffVertex // The profit/loss at zero and at every strike sorted by price
+	RightSlope float64        // The profit/loss change per unit of price above the highest strike
+}
+
+// PayoffVertex represents the profit/loss at a kink of the payoff along with its limits from either side.
+// The limits only differ from the profit/loss where a fee is charged once a contract finishes in the money.
+type PayoffVertex struct {
+	UnderlyingPrice float64
+	ProfitLoss      float64
+	LeftLimit       float64
+	RightLimit      float64
+}
+
+// BuildPayoffProfile builds the payoff profile of a set of options contracts from their profit/loss
+func BuildPayoffProfile(contracts []model.OptionsContract) PayoffProfile {
+	entryPrice := CalculateEntryPoint(contracts)
+	return buildProfile(distinctReferencePrices(contracts), func(price float64) float64 {
+		return CalculateProfitLoss(price, entryPrice, contracts)
+	})
+}
+
+// BuildNetPayoffProfile builds the payoff profile of a set of options contracts from their profit/loss net of the fees
+func BuildNetPayoffProfile(contracts []model.OptionsContract, fees model.FeeSchedule) PayoffProfile {
+	entryPrice := CalculateEntryPoint(contracts) + CalculateEntryFees(contracts, fees)
+	return buildProfile(distinctReferencePrices(contracts), func(price float64) float64 {
+		return CalculateProfitLoss(price, entryPrice, contracts) - CalculateSettlementFees(contracts, fees, price)
+	})
+}
+
+// buildProfile builds a payoff profile from a profit/loss function that is linear between the given prices.
+// The price cant go below zero so the profile starts with a vertex there.
+func buildProfile(prices []float64, profitLoss func(float64) float64) PayoffProfile {
+	if prices[0] > vertexTolerance {
+		prices = append([]float64{0}, prices...)
+	}
+
+	var profile PayoffProfile
+	for i, price := range prices {
+		vertex := PayoffVertex{UnderlyingPrice: price, ProfitLoss: profitLoss(price)}
+
+		// Extend the neighbouring segments up to the vertex, the right tail is probed a unit away and there is nothing left of zero
+		vertex.LeftLimit = vertex.ProfitLoss
+		if i > 0 {
+			vertex.LeftLimit = snapToVertex(extendSegment(profitLoss, prices[i-1], price), vertex.ProfitLoss)
+		}
+		right := price + 2
+		if i+1 < len(prices) {
+			right = prices[i+1]
+		}
+		vertex.RightLimit = snapToVertex(extendSegment(profitLoss, right, price), vertex.ProfitLoss)
+		profile.Vertices = append(profile.Vertices, vertex)
+	}
+
+	// The right tail is a straight line so a single unit step gives its slope
+	last := prices[len(prices)-1]
+	profile.RightSlope = profitLoss(last+2) - profitLoss(last+1)
+	return profile
+}
+
+// extendSegment extends the straight segment between two prices to the end price, without evaluating either end
+func extendSegment(profitLoss func(float64) float64, start, end float64) float64 {
+	a, b := start+(end-start)/3, start+2*(end-start)/3
+	profitLossA, profitLossB := profitLoss(a), profitLoss(b)
+	return profitLossB + (profitLossB-profitLossA)*(end-b)/(b-a)
+}
+
+// snapToVertex replaces a limit by the vertex profit/loss when they only differ by rounding
+func snapToVertex(limit, profitLoss float64) float64 {
+	if math.Abs(limit-profitLoss) <= limitTolerance {
+		return profitLoss
+	}
+	return limit
+}
+
+// Extremes calculates the maximum profit and maximum loss of the profile and the prices where they occur.
+// No prices are returned for an unbounded extreme.
+func (p PayoffProfile) Extremes() (maxProfit, maxLoss float64, maxProfitPrices, maxLossPrices []float64) {
+	maxProfit, maxLoss = math.Inf(-1), math.Inf(1)
+	for _, vertex := range p.Vertices {
+		maxProfit = math.Max(maxProfit, math.Max(vertex.ProfitLoss, math.Max(vertex.LeftLimit, vertex.RightLimit)))
+		maxLoss = math.Min(maxLoss, math.Min(vertex.ProfitLoss, math.Min(vertex.LeftLimit, vertex.RightLimit)))
+	}
+	for i, vertex := range p.Vertices {
+		// A flat stretch down to zero is reported at the strike it starts from, like the flat right tail
+		if i == 0 && p.flatToZero() {
+			continue
+		}
+		if vertex.reaches(maxProfit) {
+			maxProfitPrices = append(maxProfitPrices, vertex.UnderlyingPrice)
+		}
+		if vertex.reaches(maxLoss) {
+			maxLossPrices = append(maxLossPrices, vertex.UnderlyingPrice)
+		}
+	}
+
+	// The profile ends at zero so only the right tail heading up keeps increasing the profit, or heading down the loss
+	if p.RightSlope > vertexTolerance {
+		maxProfit, maxProfitPrices = math.Inf(1), nil
+	}
+	if p.RightSlope < -vertexTolerance {
+		maxLoss, maxLossPrices = math.Inf(-1), nil
+	}
+	return maxProfit, maxLoss, maxProfitPrices, maxLossPrices
+}
+
+// Roots calculates every price at which the profile crosses or touches zero, none of which are below zero
+func (p PayoffProfile) Roots() (roots []float64) {
+	last := p.Vertices[len(p.Vertices)-1]
+	for i, vertex := range p.Vertices {
+		// The vertex itself is a root, either by touching zero or by jumping across it, a flat stretch down to zero only at its strike
+		if i == 0 && p.flatToZero() {
+			continue
+		}
+		if vertex.reaches(0) || changesSign(vertex.LeftLimit, vertex.ProfitLoss) || changesSign(vertex.ProfitLoss, vertex.RightLimit) {
+			roots = append(roots, vertex.UnderlyingPrice)
+		}
+
+		// A sign change up to the next vertex means a root inside the segment
+		if i+1 < len(p.Vertices) {
+			next := p.Vertices[i+1]
+			if changesSign(vertex.RightLimit, next.LeftLimit) {
+				slope := (next.LeftLimit - vertex.RightLimit) / (next.UnderlyingPrice - vertex.UnderlyingPrice)
+				roots = append(roots, vertex.UnderlyingPrice-vertex.RightLimit/slope)
+			}
+		}
+	}
+
+	// Right tail, the line extended above the highest strike
+	if math.Abs(p.RightSlope) > vertexTolerance {
+		if root := last.UnderlyingPrice - last.RightLimit/p.RightSlope; root > last.UnderlyingPrice+vertexTolerance {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// Piecewise returns the profile itself, it is already made of straight lines
+func (p PayoffProfile) Piecewise() PayoffProfile {
+	return p
+}
+
+// flatToZero returns whether the profile is flat from the lowest strike down to zero
+func (p PayoffProfile) flatToZero() bool {
+	return len(p.Vertices) > 1 && math.Abs(p.Vertices[0].RightLimit-p.Vertices[1].LeftLimit) <= vertexTolerance
+}
+
+// reaches returns whether the vertex or either of its limits equals the value
+func (v PayoffVertex) reaches(value float64) bool {
+	return math.Abs(v.ProfitLoss-value) <= vertexTolerance ||
+		math.Abs(v.LeftLimit-value) <= vertexTolerance ||
+		math.Abs(v.RightLimit-value) <= vertexTolerance
+}
+
+// changesSign returns whether two profits lie strictly on opposite sides of zero
+func changesSign(a, b float64) bool {
+	return (a < -vertexTolerance && b > vertexTolerance) || (a > vertexTolerance && b < -vertexTolerance)
+}
+
+// distinctReferencePrices returns the sorted strike prices and cost bases of the contracts without duplicates
+func distinctReferencePrices(contracts []model.OptionsContract) []float64 {
+	var strikes []float64
+	for _, contract := range contracts {
+		strikes = append(strikes, contract.ReferencePrice())
+	}
+	sort.Float64s(strikes)
+
+	distinct := strikes[:1]
+	for _, strike := range strikes[1:] {
+		if strike-distinct[len(distinct)-1] > vertexTolerance {
+			distinct = append(distinct, strike)
+		}
+	}
+	return distinct
+}
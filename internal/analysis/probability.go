@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+const extremeTolerance = 0.005 // Half a cent, the extremes are reported to the cent
+
+// payoffSegment represents a straight stretch of the profit/loss between two prices
+type payoffSegment struct {
+	Low       float64
+	High      float64
+	Intercept float64
+	Slope     float64
+}
+
+// CalculateProbabilities calculates the probability of profit, of the maximum profit and of the maximum loss along with the expected profit/loss
+// of a profile, with the underlying price spread lognormally from the spot at the valuation time until the horizon.
+// The dividends paid until the horizon are escrowed out of the spot, which then grows at the risk-free rate net of the dividend yield
+// with the volatility of the underlying until the horizon. Without any volatility it finishes at its forward.
+func CalculateProbabilities(profile Profile, spot float64, market pricing.Market, at, horizon time.Time) model.ProbabilityAnalysis {
+	years := pricing.YearsBetween(at, horizon)
+	rate, yield, volatility := market.RiskFreeRate, market.DividendYield, market.UnderlyingVolatility(spot, horizon, at)
+	spot = pricing.EscrowedSpot(spot, pricing.ScheduleDividends(market.Dividends, at, horizon), rate)
+
+	maxProfit, maxLoss, _, _ := profile.Extremes()
+	probability := func(low, high float64) float64 {
+		return pricing.LognormalCDF(high, spot, years, rate, yield, volatility) - pricing.LognormalCDF(low, spot, years, rate, yield, volatility)
+	}
+
+	var result model.ProbabilityAnalysis
+	var expected float64
+	for _, segment := range profile.Piecewise().segments() {
+		// The break-even points split the segments into their losing and winning parts
+		if math.Abs(segment.Slope) <= vertexTolerance {
+			if segment.Intercept > vertexTolerance {
+				result.ProbabilityOfProfit += probability(segment.Low, segment.High)
+			}
+		} else if breakEven := -segment.Intercept / segment.Slope; segment.Slope > 0 && breakEven < segment.High {
+			result.ProbabilityOfProfit += probability(math.Max(segment.Low, breakEven), segment.High)
+		} else if segment.Slope < 0 && breakEven > segment.Low {
+			result.ProbabilityOfProfit += probability(segment.Low, math.Min(segment.High, breakEven))
+		}
+
+		// Only a flat stretch at an extreme has a chance of finishing there
+		if segment.isFlatAt(maxProfit) {
+			result.ProbabilityOfMaxProfit += probability(segment.Low, segment.High)
+		}
+		if segment.isFlatAt(maxLoss) {
+			result.ProbabilityOfMaxLoss += probability(segment.Low, segment.High)
+		}
+
+		// The expectation of a straight line only needs the expected price over the segment
+		expected += segment.Intercept*probability(segment.Low, segment.High) +
+			segment.Slope*pricing.LognormalPartialExpectation(segment.Low, segment.High, spot, years, rate, yield, volatility)
+	}
+	result.ExpectedProfitLoss = roundNearestHundredth(expected)
+	return result
+}
+
+// segments splits the profile into straight segments covering every price from zero up
+func (p PayoffProfile) segments() []payoffSegment {
+	last := p.Vertices[len(p.Vertices)-1]
+
+	// Both profiles start at zero so the segments between the vertices cover every price up to the right tail
+	var segments []payoffSegment
+	for i := 0; i+1 < len(p.Vertices); i++ {
+		vertex, next := p.Vertices[i], p.Vertices[i+1]
+		slope := (next.LeftLimit - vertex.RightLimit) / (next.UnderlyingPrice - vertex.UnderlyingPrice)
+		segments = append(segments, lineThrough(vertex.UnderlyingPrice, next.UnderlyingPrice, vertex.UnderlyingPrice, vertex.RightLimit, slope))
+	}
+	// Right tail, the line extended without bound
+	return append(segments, lineThrough(last.UnderlyingPrice, math.Inf(1), last.UnderlyingPrice, last.RightLimit, p.RightSlope))
+}
+
+// lineThrough builds the segment of the line through a price and its profit/loss with the given slope
+func lineThrough(low, high, price, profitLoss, slope float64) payoffSegment {
+	return payoffSegment{Low: low, High: high, Intercept: profitLoss - slope*price, Slope: slope}
+}
+
+// isFlatAt returns whether the segment stays at a bounded value from end to end
+func (s payoffSegment) isFlatAt(value float64) bool {
+	if math.IsInf(value, 0) || math.Abs(s.Intercept+s.Slope*s.Low-value) > extremeTolerance {
+		return false
+	}
+	if math.IsInf(s.High, 1) {
+		return math.Abs(s.Slope) <= vertexTolerance
+	}
+	return math.Abs(s.Intercept+s.Slope*s.High-value) <= extremeTolerance
+}
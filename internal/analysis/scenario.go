@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+// defaultStandardDeviations are the moves of the underlying a scenario grid spans when none are requested
+var defaultStandardDeviations = []float64{-3, -2, -1, 0, 1, 2, 3}
+
+// AnalyzeScenarios calculates the profit/loss of the request contracts in every scenario of the grid spanned by the axes.
+// The contracts are valued with the model at every date, shocked by every volatility shock, and a last slice settles them at the final expiration.
+// The clock tells the valuation time when the request gives none.
+func AnalyzeScenarios(request model.ScenarioRequest, clock model.Clock) model.ScenarioGrid {
+	// Price every contract with the same fill mode, natural by default
+	fillMode := request.FillMode
+	if fillMode == "" {
+		fillMode = model.Natural
+	}
+	ApplyFillMode(request.Contracts, fillMode)
+	contracts := request.Contracts
+
+	// Every scenario values the contracts in the same market from the same valuation time
+	context, market, now := requestMarket(request.AnalysisRequest(), clock)
+
+	// Fill in the defaults, the usual moves of the underlying at the valuation date without a shock
+	axes := request.Axes
+	if len(axes.StandardDeviations) == 0 {
+		axes.StandardDeviations = defaultStandardDeviations
+	}
+	if len(axes.DaysForward) == 0 {
+		axes.DaysForward = []int{0}
+	}
+	if len(axes.VolatilityShocks) == 0 {
+		axes.VolatilityShocks = []float64{0}
+	}
+
+	grid := model.ScenarioGrid{
+		UnderlyingPrices: CalculateScenarioMoves(contracts, axes.StandardDeviations, context.UnderlyingPrice, market, now),
+		VolatilityShocks: axes.VolatilityShocks,
+	}
+	_, grid.Warnings = model.CheckSanity(request.AnalysisRequest())
+	for _, days := range axes.DaysForward {
+		grid.Dates = append(grid.Dates, model.ScenarioDate{DaysForward: days, ValuationDate: now.AddDate(0, 0, days)})
+	}
+	if final, ok := FinalExpiration(contracts); ok {
+		days := int(math.Ceil(final.Sub(now).Hours() / 24))
+		grid.Dates = append(grid.Dates, model.ScenarioDate{DaysForward: max(0, days), ValuationDate: final, Expiry: true})
+	}
+
+	entryPrice := CalculateEntryPoint(contracts)
+	for _, date := range grid.Dates {
+		slice := make([][]float64, 0, len(axes.VolatilityShocks))
+		for _, shock := range axes.VolatilityShocks {
+			shocked := market.WithShock(shock)
+			row := make([]float64, 0, len(grid.UnderlyingPrices))
+			for _, move := range grid.UnderlyingPrices {
+				// Every contract has settled at the final expiration, where the profit/loss is the payoff whatever the volatility
+				var profitLoss float64
+				if date.Expiry {
+					profitLoss = CalculateTotalProfit(contracts, move.UnderlyingPrice)
+				} else {
+					profitLoss = CalculateTheoreticalValue(contracts, move.UnderlyingPrice, shocked, date.ValuationDate) - entryPrice
+				}
+				row = append(row, roundNearestHundredth(profitLoss))
+			}
+			slice = append(slice, row)
+		}
+		grid.ProfitLoss = append(grid.ProfitLoss, slice)
+	}
+	return grid
+}
+
+// CalculateScenarioMoves calculates the underlying prices the given standard deviations away from the spot,
+// with the lognormal spread of the underlying until the nearest expiration
+func CalculateScenarioMoves(contracts []model.OptionsContract, standardDeviations []float64, spot float64, market pricing.Market, now time.Time) []model.ScenarioMove {
+	// Without options the moves are spread over a year
+	horizon, ok := NearestExpiration(contracts)
+	if !ok {
+		horizon = now.AddDate(1, 0, 0)
+	}
+	years := pricing.YearsBetween(now, horizon)
+	spread := market.UnderlyingVolatility(spot, horizon, now) * math.Sqrt(years)
+
+	moves := make([]model.ScenarioMove, 0, len(standardDeviations))
+	for _, deviations := range standardDeviations {
+		price := roundNearestHundredth(spot * math.Exp(deviations*spread))
+		moves = append(moves, model.ScenarioMove{StandardDeviations: deviations, UnderlyingPrice: price})
+	}
+	return moves
+}
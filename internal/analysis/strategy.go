@@ -0,0 +1,291 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+const CUSTOM_STRATEGY = "Custom"
+
+// strategyLeg represents the net position held in one instrument of a strategy
+type strategyLeg struct {
+	Type           model.OptionType
+	StrikePrice    float64
+	ExpirationDate time.Time
+	Units          float64 // Signed units of the underlying, negative for a short position
+}
+
+// ClassifyStrategy names the strategy formed by a set of contracts and reports its bias and premium type
+func ClassifyStrategy(contracts []model.OptionsContract) model.Strategy {
+	return model.Strategy{
+		Name:        NameStrategy(contracts),
+		Bias:        DetermineBias(contracts),
+		PremiumType: DeterminePremiumType(CalculateEntryPoint(contracts)),
+	}
+}
+
+// NameStrategy names the strategy formed by a set of contracts, or Custom when it is not a known one
+func NameStrategy(contracts []model.OptionsContract) string {
+	var options []strategyLeg
+	var underlying []strategyLeg
+	for _, leg := range aggregateLegs(contracts) {
+		if leg.Type == model.Call || leg.Type == model.Put {
+			options = append(options, leg)
+		} else {
+			underlying = append(underlying, leg)
+		}
+	}
+
+	switch {
+	case len(underlying) == 0:
+		return nameOptionStrategy(options)
+	case len(underlying) == 1 && len(options) == 0:
+		return side(underlying[0]) + " " + string(underlying[0].Type)
+	case len(underlying) == 1:
+		return nameCoveredStrategy(underlying[0], options)
+	}
+	return CUSTOM_STRATEGY
+}
+
+// DetermineBias determines the market direction a set of contracts profits from by comparing its payoff beyond both ends of the strikes
+func DetermineBias(contracts []model.OptionsContract) model.Bias {
+	prices := distinctReferencePrices(contracts)
+	buffer := math.Max(1, prices[len(prices)-1]-prices[0])
+	entryPrice := CalculateEntryPoint(contracts)
+	low := CalculateProfitLoss(prices[0]-buffer, entryPrice, contracts)
+	high := CalculateProfitLoss(prices[len(prices)-1]+buffer, entryPrice, contracts)
+
+	tolerance := limitTolerance * (1 + math.Abs(low) + math.Abs(high))
+	switch {
+	case high-low > tolerance:
+		return model.Bullish
+	case low-high > tolerance:
+		return model.Bearish
+	default:
+		return model.Neutral
+	}
+}
+
+// nameOptionStrategy names a strategy made of options only
+func nameOptionStrategy(legs []strategyLeg) string {
+	switch len(legs) {
+	case 1:
+		return side(legs[0]) + " " + string(legs[0].Type)
+	case 2:
+		return nameTwoLegStrategy(legs[0], legs[1])
+	case 3:
+		return nameThreeLegStrategy(legs[0], legs[1], legs[2])
+	case 4:
+		return nameFourLegStrategy(legs[0], legs[1], legs[2], legs[3])
+	}
+	return CUSTOM_STRATEGY
+}
+
+// nameTwoLegStrategy names verticals, ratio spreads, calendars, diagonals, straddles, strangles and synthetics
+func nameTwoLegStrategy(a, b strategyLeg) string {
+	opposite := (a.Units > 0) != (b.Units > 0)
+
+	// Legs of different expirations are calendars or diagonals
+	if !a.ExpirationDate.Equal(b.ExpirationDate) {
+		if a.Type != b.Type || !opposite {
+			return CUSTOM_STRATEGY
+		}
+		if a.StrikePrice == b.StrikePrice {
+			return string(a.Type) + " Calendar Spread"
+		}
+		return string(a.Type) + " Diagonal Spread"
+	}
+
+	if a.Type == b.Type {
+		if !opposite {
+			return CUSTOM_STRATEGY
+		}
+		// A different size on each side makes it a ratio spread, or a backspread when the long side is larger
+		if math.Abs(a.Units) != math.Abs(b.Units) {
+			if a.Units+b.Units > 0 {
+				return string(a.Type) + " Backspread"
+			}
+			return string(a.Type) + " Ratio Spread"
+		}
+		// Buying the lower strike profits from a rise for both calls and puts
+		if a.Units > 0 {
+			return "Bull " + string(a.Type) + " Spread"
+		}
+		return "Bear " + string(a.Type) + " Spread"
+	}
+
+	// A call and a put on the same side are straddles or strangles
+	if !opposite {
+		if a.StrikePrice == b.StrikePrice {
+			return side(a) + " Straddle"
+		}
+		return side(a) + " Strangle"
+	}
+
+	// A long call against a short put replicates the stock and the reverse replicates a short stock
+	call := a
+	if b.Type == model.Call {
+		call = b
+	}
+	if a.StrikePrice != b.StrikePrice {
+		return "Risk Reversal"
+	}
+	if call.Units > 0 {
+		return "Synthetic Long Stock"
+	}
+	return "Synthetic Short Stock"
+}
+
+// nameThreeLegStrategy names butterflies and jade lizards
+func nameThreeLegStrategy(a, b, c strategyLeg) string {
+	if !sameExpiration(a, b, c) {
+		return CUSTOM_STRATEGY
+	}
+
+	// A butterfly buys or sells the wings once and the body twice on the same type
+	if a.Type == b.Type && b.Type == c.Type && a.Units == c.Units && b.Units == -2*a.Units {
+		name := side(a) + " " + string(a.Type) + " Butterfly"
+		if b.StrikePrice-a.StrikePrice != c.StrikePrice-b.StrikePrice {
+			name = side(a) + " " + string(a.Type) + " Broken Wing Butterfly"
+		}
+		return name
+	}
+
+	// A jade lizard sells a put below a short call spread, the reverse sells a call above a short put spread
+	if a.Type == model.Put && b.Type == model.Call && c.Type == model.Call &&
+		a.Units < 0 && b.Units < 0 && c.Units == -b.Units {
+		return "Jade Lizard"
+	}
+	if a.Type == model.Put && b.Type == model.Put && c.Type == model.Call &&
+		c.Units < 0 && b.Units < 0 && a.Units == -b.Units {
+		return "Reverse Jade Lizard"
+	}
+	return CUSTOM_STRATEGY
+}
+
+// nameFourLegStrategy names iron condors, iron butterflies, condors and box spreads
+func nameFourLegStrategy(a, b, c, d strategyLeg) string {
+	if !sameExpiration(a, b, c, d) {
+		return CUSTOM_STRATEGY
+	}
+	wings := a.Units == d.Units && b.Units == c.Units && a.Units == -b.Units
+
+	// A put spread below a call spread, selling the inner strikes is the credit version
+	if a.Type == model.Put && b.Type == model.Put && c.Type == model.Call && d.Type == model.Call && wings {
+		name := "Iron Condor"
+		if b.StrikePrice == c.StrikePrice {
+			name = "Iron Butterfly"
+		}
+		if a.Units < 0 {
+			name = "Reverse " + name
+		}
+		return name
+	}
+
+	// Four strikes of the same type with the inner strikes on the other side
+	if a.Type == b.Type && b.Type == c.Type && c.Type == d.Type && wings {
+		return side(a) + " " + string(a.Type) + " Condor"
+	}
+
+	// A bull call spread and a bear put spread on the same two strikes lock in the difference
+	if a.StrikePrice == b.StrikePrice && c.StrikePrice == d.StrikePrice && a.StrikePrice != c.StrikePrice &&
+		a.Type == model.Put && b.Type == model.Call && c.Type == model.Put && d.Type == model.Call &&
+		b.Units == -d.Units && c.Units == -a.Units && b.Units == -a.Units {
+		return side(b) + " Box Spread"
+	}
+	return CUSTOM_STRATEGY
+}
+
+// nameCoveredStrategy names the strategies combining a stock or futures position with options
+func nameCoveredStrategy(underlying strategyLeg, options []strategyLeg) string {
+	long := underlying.Units > 0
+	switch len(options) {
+	case 1:
+		option := options[0]
+		switch {
+		case long && option.Type == model.Call && option.Units < 0:
+			return "Covered Call"
+		case long && option.Type == model.Put && option.Units > 0:
+			return "Protective Put"
+		case !long && option.Type == model.Put && option.Units < 0:
+			return "Covered Put"
+		case !long && option.Type == model.Call && option.Units > 0:
+			return "Protective Call"
+		}
+	case 2:
+		put, call := options[0], options[1]
+		if put.Type != model.Put || call.Type != model.Call || put.Units != -call.Units {
+			return CUSTOM_STRATEGY
+		}
+		switch {
+		case long && put.Units > 0 && put.StrikePrice == call.StrikePrice:
+			return "Conversion"
+		case long && put.Units > 0 && put.StrikePrice < call.StrikePrice:
+			return "Collar"
+		case !long && put.Units < 0 && put.StrikePrice == call.StrikePrice:
+			return "Reversal"
+		}
+	}
+	return CUSTOM_STRATEGY
+}
+
+// aggregateLegs nets the contracts held in the same instrument and sorts them by strike, puts before calls
+func aggregateLegs(contracts []model.OptionsContract) []strategyLeg {
+	var legs []strategyLeg
+	for _, contract := range contracts {
+		leg := strategyLeg{Type: contract.Type, StrikePrice: contract.ReferencePrice(), ExpirationDate: contract.ExpirationDate, Units: PositionSize(contract)}
+		// The underlying is the same instrument whatever its cost basis
+		if !contract.IsOption() {
+			leg.StrikePrice = 0
+		}
+
+		merged := false
+		for i := range legs {
+			if legs[i].Type == leg.Type && legs[i].StrikePrice == leg.StrikePrice && legs[i].ExpirationDate.Equal(leg.ExpirationDate) {
+				legs[i].Units += leg.Units
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			legs = append(legs, leg)
+		}
+	}
+
+	// Drop the legs that cancel out
+	netLegs := legs[:0]
+	for _, leg := range legs {
+		if leg.Units != 0 {
+			netLegs = append(netLegs, leg)
+		}
+	}
+
+	sort.SliceStable(netLegs, func(i, j int) bool {
+		if netLegs[i].StrikePrice != netLegs[j].StrikePrice {
+			return netLegs[i].StrikePrice < netLegs[j].StrikePrice
+		}
+		return netLegs[i].Type == model.Put && netLegs[j].Type != model.Put
+	})
+	return netLegs
+}
+
+// sameExpiration returns whether every leg expires at the same time
+func sameExpiration(legs ...strategyLeg) bool {
+	for _, leg := range legs[1:] {
+		if !leg.ExpirationDate.Equal(legs[0].ExpirationDate) {
+			return false
+		}
+	}
+	return true
+}
+
+// side returns the side of the position held in a leg
+func side(leg strategyLeg) string {
+	if leg.Units < 0 {
+		return "Short"
+	}
+	return "Long"
+}
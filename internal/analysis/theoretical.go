@@ -0,0 +1,59 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+)
+
+// CalculateTheoreticalCurves calculates the model profit/loss curves for each requested number of days forward
+func CalculateTheoreticalCurves(request model.AnalysisRequest, prices []float64, market pricing.Market, now time.Time) []model.TheoreticalCurve {
+	// Default to the T+0 curve when no days were requested
+	curveDays := request.CurveDays
+	if len(curveDays) == 0 {
+		curveDays = []int{0}
+	}
+
+	// The entry fees are already paid whatever the valuation date
+	entryFees := 0.0
+	if request.Fees != nil {
+		entryFees = CalculateEntryFees(request.Contracts, *request.Fees)
+	}
+
+	var curves []model.TheoreticalCurve
+	for _, days := range curveDays {
+		valuationDate := now.AddDate(0, 0, days)
+		graph := CalculateTheoreticalCurve(request.Contracts, prices, market, valuationDate)
+		for i := range graph {
+			graph[i].ProfitLoss = roundNearestHundredth(graph[i].ProfitLoss - entryFees)
+		}
+		curves = append(curves, model.TheoreticalCurve{
+			DaysForward:     days,
+			ValuationDate:   valuationDate,
+			RiskRewardGraph: graph,
+		})
+	}
+	return curves
+}
+
+// CalculateTheoreticalCurve calculates the model profit/loss of the contracts at every price for the given valuation date
+func CalculateTheoreticalCurve(contracts []model.OptionsContract, prices []float64, market pricing.Market, at time.Time) []model.RiskRewardGraph {
+	entryPrice := CalculateEntryPoint(contracts)
+
+	var graph []model.RiskRewardGraph
+	for _, price := range prices {
+		profitLoss := CalculateTheoreticalValue(contracts, price, market, at) - entryPrice
+		graph = append(graph, model.RiskRewardGraph{UnderlyingPrice: price, ProfitLoss: roundNearestHundredth(profitLoss)})
+	}
+	return graph
+}
+
+// CalculateTheoreticalValue calculates the model value of a set of options contracts at a given price
+func CalculateTheoreticalValue(contracts []model.OptionsContract, price float64, market pricing.Market, at time.Time) float64 {
+	value := 0.0
+	for _, contract := range contracts {
+		value += pricing.ContractValue(contract, price, market, at) * PositionSize(contract)
+	}
+	return value
+}
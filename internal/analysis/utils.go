@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"math"
+	"strconv"
 
 	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
 )
@@ -11,6 +12,20 @@ func roundNearestHundredth(x float64) float64 {
 	return math.Ceil(x*100) / 100
 }
 
+// formatProfitLoss formats a profit or loss rounded to two decimal places, an unbounded one as +Inf or -Inf
+func formatProfitLoss(x float64) string {
+	return strconv.FormatFloat(roundNearestHundredth(x), 'f', 2, 64)
+}
+
+// boundedProfitLoss rounds a profit or loss to two decimal places like formatProfitLoss, it returns nil for an unbounded one
+func boundedProfitLoss(x float64) *float64 {
+	if math.IsInf(x, 0) {
+		return nil
+	}
+	rounded := roundNearestHundredth(x)
+	return &rounded
+}
+
 // CallRisk calculates the risk for a call option given the current price and strike price
 func CallRisk(price, strike float64) float64 {
 	return math.Max(0, price-strike)
@@ -21,33 +36,23 @@ func PutRisk(price, strike float64) float64 {
 	return math.Max(0, strike-price)
 }
 
+// PositionSize returns the signed units of the underlying a contract covers, negative for a short position
+func PositionSize(contract model.OptionsContract) float64 {
+	if contract.LongShort == model.Short {
+		return -contract.Units()
+	}
+	return contract.Units()
+}
+
 // CalculateEntryPoint calculates the total entry point for a set of options contracts
 func CalculateEntryPoint(contracts []model.OptionsContract) float64 {
 	profit := 0.0
 
-	// Iterate over each contract to sum up the entry costs
+	// Iterate over each contract to sum up the entry costs, paid for long and received for short contracts
 	for _, contract := range contracts {
-		switch contract.Type {
-		case model.Call:
-			if contract.LongShort == model.Long {
-				profit += contract.Ask // For long call, add the ask price
-			} else {
-				profit -= contract.Ask // For short call, subtract the ask price
-			}
-		case model.Put:
-			if contract.LongShort == model.Long {
-				profit += contract.Bid // For long put, add the bid price
-			} else {
-				profit -= contract.Bid // For short put, subtract the bid price
-			}
-		}
+		profit += CalculateFillPrice(contract, model.Natural) * PositionSize(contract)
 	}
 
 	// Round the total entry point to two decimal places
 	return roundNearestHundredth(profit)
 }
-
-// MultiplyBySharesAmount multiplies a given float64 value by a specified shares count
-func MultiplyBySharesAmount(value, factor float64) float64 {
-	return roundNearestHundredth(value * factor)
-}
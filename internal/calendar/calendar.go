@@ -0,0 +1,335 @@
+package calendar
+
+import (
+	"sync"
+	"time"
+	_ "time/tzdata"
+)
+
+const (
+	OPEN_HOUR         = 9        // Hour the regular session opens at, New York time
+	OPEN_MINUTE       = 30       // Minute the regular session opens at, New York time
+	CLOSE_HOUR        = 16       // Hour the regular session closes at, New York time
+	EARLY_CLOSE_HOUR  = 13       // Hour the session closes at on the eves of some holidays, New York time
+	JUNETEENTH_SINCE  = 2022     // First year the exchanges close for Juneteenth
+	MAX_CALENDAR_DAYS = 366 * 30 // Most days a trading day count runs through before giving up
+)
+
+// NewYork is the time zone the US equity and options exchanges keep their hours in.
+// The functions taking a date read its day in its own time zone, so a date sent as midnight UTC is the same day in New York.
+var NewYork = mustLoadLocation("America/New_York")
+
+type Settlement string
+
+const (
+	PM Settlement = "pm" // Settled on the closing prices of the expiration day, the default
+	AM Settlement = "am" // Settled on the opening prices of the expiration day, the last trade is the day before
+)
+
+// Holiday returns the name of the exchange holiday the date falls on, and whether it is one
+func Holiday(date time.Time) (string, bool) {
+	year, month, day := date.Date()
+	return calendarOf(year).holiday(month, day)
+}
+
+// IsTradingDay returns whether the exchanges hold a session on the date, a weekday that is not a holiday
+func IsTradingDay(date time.Time) bool {
+	weekday := date.Weekday()
+	if weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	_, holiday := Holiday(date)
+	return !holiday
+}
+
+// IsEarlyClose returns whether the session of the date closes early, on the eve of Independence Day and Christmas and the day after Thanksgiving
+func IsEarlyClose(date time.Time) bool {
+	if !IsTradingDay(date) {
+		return false
+	}
+	// The eves falling on a Friday are already holidays, observed in place of the Saturday
+	year, month, day := date.Date()
+	switch {
+	case month == time.July && day == 3, month == time.December && day == 24:
+		return true
+	case month == time.November:
+		return day == nthWeekday(year, time.November, time.Thursday, 4)+1
+	}
+	return false
+}
+
+// SessionOpen returns the instant the session of the date opens
+func SessionOpen(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, OPEN_HOUR, OPEN_MINUTE, 0, 0, NewYork)
+}
+
+// SessionClose returns the instant the session of the date closes, early on the eves of some holidays
+func SessionClose(date time.Time) time.Time {
+	year, month, day := date.Date()
+	if IsEarlyClose(date) {
+		return time.Date(year, month, day, EARLY_CLOSE_HOUR, 0, 0, 0, NewYork)
+	}
+	return time.Date(year, month, day, CLOSE_HOUR, 0, 0, 0, NewYork)
+}
+
+// PreviousTradingDay returns the last trading day on or before the date
+func PreviousTradingDay(date time.Time) time.Time {
+	year, month, day := date.Date()
+	date = time.Date(year, month, day, 0, 0, 0, 0, NewYork)
+	for !IsTradingDay(date) {
+		date = date.AddDate(0, 0, -1)
+	}
+	return date
+}
+
+// ExpiryInstant returns the instant a contract expiring on the date settles at. A bare date, at midnight in its own time zone or in New York,
+// settles at the close of its session, or at the open for AM settlement, and a date the exchanges are closed on rolls back to the
+// trading day before it. A date with a time of day is already an instant and is returned as is.
+func ExpiryInstant(expiration time.Time, settlement Settlement) time.Time {
+	if expiration.IsZero() || !IsBareDate(expiration) {
+		return expiration
+	}
+	// A date at midnight in New York but not in its own time zone, like 05:00 UTC, is the New York day
+	year, month, day := expiration.Date()
+	if !isMidnight(expiration) {
+		year, month, day = expiration.In(NewYork).Date()
+	}
+	session := PreviousTradingDay(time.Date(year, month, day, 12, 0, 0, 0, NewYork))
+	if settlement == AM {
+		return SessionOpen(session)
+	}
+	return SessionClose(session)
+}
+
+// IsBareDate returns whether the time is a calendar date without a time of day, midnight in its own time zone or in New York
+func IsBareDate(t time.Time) bool {
+	return isMidnight(t) || isMidnight(t.In(NewYork))
+}
+
+// isMidnight returns whether the time is midnight in its time zone
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// TradingDaysToExpiry counts the sessions left to trade a contract from a time until it expires,
+// the sessions that close after the time and open before the expiry, the current one included
+func TradingDaysToExpiry(from, expiry time.Time) int {
+	if !expiry.After(from) {
+		return 0
+	}
+	expiry = earliest(expiry, from.AddDate(0, 0, MAX_CALENDAR_DAYS))
+	first, last := sessionDate(from), sessionDate(expiry)
+	days := sessionsBetween(first, last.AddDate(0, 0, 1))
+	if IsTradingDay(first) && !SessionClose(first).After(from) {
+		days--
+	}
+	if IsTradingDay(last) && !SessionOpen(last).Before(expiry) {
+		days--
+	}
+	return days
+}
+
+// TradingTime measures the time from one instant to another in sessions. Every session counts as one, prorated over its hours,
+// and the time the exchanges are closed counts for nothing
+func TradingTime(from, to time.Time) float64 {
+	if !to.After(from) {
+		return 0
+	}
+	to = earliest(to, from.AddDate(0, 0, MAX_CALENDAR_DAYS))
+	first, last := sessionDate(from), sessionDate(to)
+	if first.Equal(last) {
+		return sessionFraction(first, from, to)
+	}
+	return sessionFraction(first, from, to) + float64(sessionsBetween(first.AddDate(0, 0, 1), last)) + sessionFraction(last, from, to)
+}
+
+// AddTradingTime returns the instant a number of sessions of trading time after another, the inverse of TradingTime
+func AddTradingTime(from time.Time, sessions float64) time.Time {
+	if sessions <= 0 {
+		return from
+	}
+	date := sessionDate(from)
+	for i := 0; i < MAX_CALENDAR_DAYS; i++ {
+		if IsTradingDay(date) {
+			open, close := SessionOpen(date), SessionClose(date)
+			length := close.Sub(open)
+			start := latest(open, from)
+			// The part of the session still ahead, all of it past the first day
+			if left := close.Sub(start).Seconds() / length.Seconds(); left > 0 {
+				if sessions <= left {
+					return start.Add(time.Duration(sessions * float64(length)))
+				}
+				sessions -= left
+			}
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// sessionFraction returns the share of the session of the date that is held between two instants
+func sessionFraction(date, from, to time.Time) float64 {
+	if !IsTradingDay(date) {
+		return 0
+	}
+	open, close := SessionOpen(date), SessionClose(date)
+	held := earliest(close, to).Sub(latest(open, from))
+	return max(0, held.Seconds()/close.Sub(open).Seconds())
+}
+
+// sessionsBetween counts the sessions held from the first date up to but excluding the last
+func sessionsBetween(first, last time.Time) int {
+	count := 0
+	for year := first.Year(); year < last.Year(); year++ {
+		sessions := calendarOf(year).sessions
+		count += sessions[len(sessions)-1]
+	}
+	return count - calendarOf(first.Year()).sessions[first.YearDay()] + calendarOf(last.Year()).sessions[last.YearDay()]
+}
+
+// sessionDate returns the day of an instant in New York, at noon to stay clear of the daylight saving changes
+func sessionDate(t time.Time) time.Time {
+	year, month, day := t.In(NewYork).Date()
+	return time.Date(year, month, day, 12, 0, 0, 0, NewYork)
+}
+
+// earliest returns the earlier of two instants
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// latest returns the later of two instants
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// tradingYear represents the exchange calendar of a year, worked out once since it is looked up for every valuation
+type tradingYear struct {
+	holidays []holiday
+	sessions []int // Sessions held before every day of the year, by day of the year and up to the day after the last
+}
+
+// tradingYears keeps the calendars of the years already worked out
+var tradingYears sync.Map
+
+// calendarOf returns the exchange calendar of a year
+func calendarOf(year int) *tradingYear {
+	if cached, ok := tradingYears.Load(year); ok {
+		return cached.(*tradingYear)
+	}
+	calendar := &tradingYear{holidays: holidays(year)}
+	days := time.Date(year, time.December, 31, 12, 0, 0, 0, NewYork).YearDay()
+	calendar.sessions = make([]int, days+2)
+	for day := 1; day <= days; day++ {
+		date := time.Date(year, time.January, day, 12, 0, 0, 0, NewYork)
+		calendar.sessions[day+1] = calendar.sessions[day]
+		_, holiday := calendar.holiday(date.Month(), date.Day())
+		if weekday := date.Weekday(); weekday != time.Saturday && weekday != time.Sunday && !holiday {
+			calendar.sessions[day+1]++
+		}
+	}
+	tradingYears.Store(year, calendar)
+	return calendar
+}
+
+// holiday returns the name of the holiday on a day of the year, and whether there is one
+func (y *tradingYear) holiday(month time.Month, day int) (string, bool) {
+	for _, holiday := range y.holidays {
+		if holiday.month == month && holiday.day == day {
+			return holiday.name, true
+		}
+	}
+	return "", false
+}
+
+// holiday represents an exchange holiday on a date of a year
+type holiday struct {
+	name  string
+	month time.Month
+	day   int
+}
+
+// holidays returns the US equity exchange holidays of a year on the dates they are observed
+func holidays(year int) []holiday {
+	easter := easterSunday(year)
+	goodFriday := easter.AddDate(0, 0, -2)
+	list := []holiday{
+		{"Martin Luther King Jr. Day", time.January, nthWeekday(year, time.January, time.Monday, 3)},
+		{"Washington's Birthday", time.February, nthWeekday(year, time.February, time.Monday, 3)},
+		{"Good Friday", goodFriday.Month(), goodFriday.Day()},
+		{"Memorial Day", time.May, lastWeekday(year, time.May, time.Monday)},
+		observed("Independence Day", year, time.July, 4),
+		{"Labor Day", time.September, nthWeekday(year, time.September, time.Monday, 1)},
+		{"Thanksgiving Day", time.November, nthWeekday(year, time.November, time.Thursday, 4)},
+		observed("Christmas Day", year, time.December, 25),
+	}
+	// New Year's Day on a Saturday is not made up on the Friday before, which would close the prior year
+	if newYear := observed("New Year's Day", year, time.January, 1); newYear.month == time.January {
+		list = append(list, newYear)
+	}
+	if year >= JUNETEENTH_SINCE {
+		list = append(list, observed("Juneteenth National Independence Day", year, time.June, 19))
+	}
+	return list
+}
+
+// observed returns the holiday on the date it is observed, the Friday before when it falls on a Saturday and the Monday after on a Sunday
+func observed(name string, year int, month time.Month, day int) holiday {
+	date := time.Date(year, month, day, 12, 0, 0, 0, NewYork)
+	switch date.Weekday() {
+	case time.Saturday:
+		date = date.AddDate(0, 0, -1)
+	case time.Sunday:
+		date = date.AddDate(0, 0, 1)
+	}
+	return holiday{name, date.Month(), date.Day()}
+}
+
+// nthWeekday returns the day of the month of its nth weekday
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) int {
+	first := time.Date(year, month, 1, 12, 0, 0, 0, NewYork)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return 1 + offset + 7*(n-1)
+}
+
+// lastWeekday returns the day of the month of its last weekday
+func lastWeekday(year int, month time.Month, weekday time.Weekday) int {
+	last := time.Date(year, month+1, 0, 12, 0, 0, 0, NewYork)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.Day() - offset
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar, with the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 12, 0, 0, 0, NewYork)
+}
+
+// mustLoadLocation loads a time zone from the embedded database
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
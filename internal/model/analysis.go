@@ -1,11 +1,31 @@
 package model
 
+import "time"
+
 // Analysis represents the data structure of the analysis result
 type Analysis struct {
-	RiskRewardGraph []RiskRewardGraph `json:"risk_reward_graph"`
-	MaxProfit       string            `json:"max_profit"`
-	MaxLoss         string            `json:"max_loss"`
-	BreakEvenPoints []float64         `json:"break_even_points"`
+	RiskRewardGraph     []RiskRewardGraph      `json:"risk_reward_graph"`
+	MaxProfit           string                 `json:"max_profit"`
+	MaxLoss             string                 `json:"max_loss"`
+	MaxProfitPrices     []float64              `json:"max_profit_prices,omitempty"`
+	MaxLossPrices       []float64              `json:"max_loss_prices,omitempty"`
+	BreakEvenPoints     []float64              `json:"break_even_points"`
+	FillMode            FillMode               `json:"fill_mode,omitempty"`
+	NetPremium          float64                `json:"net_premium"`
+	PremiumType         PremiumType            `json:"premium_type"`
+	Fees                *FeeAnalysis           `json:"fees,omitempty"`
+	EvaluationDate      *time.Time             `json:"evaluation_date,omitempty"`
+	Strategy            Strategy               `json:"strategy"`
+	Metrics             RiskMetrics            `json:"metrics"`
+	Probabilities       *ProbabilityAnalysis   `json:"probabilities,omitempty"`
+	Simulation          *SimulationAnalysis    `json:"simulation,omitempty"`
+	TheoreticalCurves   []TheoreticalCurve     `json:"theoretical_curves,omitempty"`
+	Greeks              *PositionGreeks        `json:"greeks,omitempty"`
+	ImpliedVolatilities []LegImpliedVolatility `json:"implied_volatilities,omitempty"`
+	ExerciseBoundaries  []ExerciseBoundary     `json:"exercise_boundaries,omitempty"`
+	Margin              *MarginAnalysis        `json:"margin,omitempty"`
+	Expirations         []LegExpiration        `json:"expirations,omitempty"`
+	Warnings            []ValidationError      `json:"warnings,omitempty"` // Market-sanity rules the contracts break that do not fail the request
 }
 
 // RiskRewardGraph represents a pair of X and Y values
@@ -13,3 +33,10 @@ type RiskRewardGraph struct {
 	UnderlyingPrice float64 `json:"underlying_price"`
 	ProfitLoss      float64 `json:"profit_loss"`
 }
+
+// TheoreticalCurve represents the model profit/loss of the position a number of days from today
+type TheoreticalCurve struct {
+	DaysForward     int               `json:"days_forward"`
+	ValuationDate   time.Time         `json:"valuation_date"`
+	RiskRewardGraph []RiskRewardGraph `json:"risk_reward_graph"`
+}
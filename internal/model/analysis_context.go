@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Carry represents the cost of holding the underlying, the risk-free rate it is financed at and the dividends it pays
+type Carry struct {
+	RiskFreeRate  float64    `json:"risk_free_rate"`
+	DividendYield float64    `json:"dividend_yield"` // Continuous dividend yield
+	Dividends     []Dividend `json:"dividends,omitempty"`
+}
+
+// AnalysisContext represents the market the contracts are valued in
+type AnalysisContext struct {
+	UnderlyingPrice float64 `json:"underlying_price"`
+	Carry
+	ValuationDate *time.Time `json:"valuation_date,omitempty"` // The time the contracts are valued at, now by default
+}
+
+func IsAnalysisContextValid(context AnalysisContext) error {
+	return firstError(ValidateAnalysisContext(context))
+}
+
+// ValidateAnalysisContext checks every field of an analysis context and returns all the errors found
+func ValidateAnalysisContext(context AnalysisContext) []ValidationError {
+	var errs []ValidationError
+	// The underlying price cant be negative
+	if context.UnderlyingPrice < 0 {
+		errs = append(errs, fieldError("underlying_price", CodeNegative, "underlying price must be non-negative"))
+	}
+	// The dividends are either paid continuously or on given dates
+	if context.DividendYield != 0 && len(context.Dividends) > 0 {
+		errs = append(errs, fieldError("dividends", CodeConflict, "dividend yield and dividends cannot both be given"))
+	}
+	// Check that the dividends are correct
+	for i, dividend := range context.Dividends {
+		errs = append(errs, nested(fmt.Sprintf("dividends[%d]", i), ValidateDividend(dividend))...)
+	}
+	return errs
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AnalysisRequest represents the data structure of an analysis request
+type AnalysisRequest struct {
+	Contracts         []OptionsContract   `json:"contracts"`
+	UnderlyingPrice   float64             `json:"underlying_price"`
+	Volatility        float64             `json:"volatility"`
+	RiskFreeRate      float64             `json:"risk_free_rate"`
+	CurveDays         []int               `json:"curve_days"`
+	FillMode          FillMode            `json:"fill_mode"`
+	Fees              *FeeSchedule        `json:"fees,omitempty"`
+	EvaluationDate    *time.Time          `json:"evaluation_date,omitempty"`
+	ValuationDate     *time.Time          `json:"valuation_date,omitempty"` // The time the contracts are checked and valued at, the context's or now by default
+	Simulation        *SimulationSettings `json:"simulation,omitempty"`
+	Context           *AnalysisContext    `json:"context,omitempty"`
+	VolatilitySurface *VolatilitySurface  `json:"volatility_surface,omitempty"`
+	Sanity            *SanitySettings     `json:"sanity,omitempty"`
+}
+
+// UnmarshalJSON accepts either a full analysis request or a bare array of contracts
+func (r *AnalysisRequest) UnmarshalJSON(data []byte) error {
+	// A bare array is the original request shape and only carries the contracts
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		*r = AnalysisRequest{}
+		return json.Unmarshal(trimmed, &r.Contracts)
+	}
+
+	// Use an alias so the decoding does not recurse back into this method
+	type alias AnalysisRequest
+	var request alias
+	if err := json.Unmarshal(data, &request); err != nil {
+		return err
+	}
+	*r = AnalysisRequest(request)
+	return nil
+}
+
+// ResolveContext returns the analysis context of the request, which falls back on the underlying price and risk-free rate given alongside the contracts
+func (r AnalysisRequest) ResolveContext() AnalysisContext {
+	var context AnalysisContext
+	if r.Context != nil {
+		context = *r.Context
+	}
+	if context.UnderlyingPrice == 0 {
+		context.UnderlyingPrice = r.UnderlyingPrice
+	}
+	if context.RiskFreeRate == 0 {
+		context.RiskFreeRate = r.RiskFreeRate
+	}
+	if context.ValuationDate == nil {
+		context.ValuationDate = r.ValuationDate
+	}
+	return context
+}
+
+// ValuationTime returns the time the contracts of the request are checked and valued at, the time of the clock when the request gives none
+func (r AnalysisRequest) ValuationTime(clock Clock) time.Time {
+	if valuationDate := r.ResolveContext().ValuationDate; valuationDate != nil {
+		return *valuationDate
+	}
+	return clock.Now()
+}
+
+// HasVolatility returns whether the request gives a volatility, flat or as a surface
+func (r AnalysisRequest) HasVolatility() bool {
+	return r.Volatility > 0 || r.VolatilitySurface != nil
+}
+
+// MixedExpirationWarnings warns that contracts expiring on different dates are analyzed at their payoff as if they all expired together
+// when there is no volatility to value them at the nearest expiration
+func MixedExpirationWarnings(request AnalysisRequest) []ValidationError {
+	if request.EvaluationDate != nil || request.HasVolatility() || !HasMixedExpirations(request.Contracts) {
+		return nil
+	}
+	return []ValidationError{fieldError("volatility", CodeRequired, "volatility is required to evaluate contracts at the nearest expiration, every contract is analyzed at its payoff as if they all expired together and the time value left in the later ones is ignored")}
+}
+
+func IsAnalysisRequestValid(request AnalysisRequest) error {
+	return firstError(ValidateAnalysisRequest(request))
+}
+
+// ValidateAnalysisRequest checks every model input of an analysis request and returns all the errors found, the contracts are checked by ValidateContracts
+func ValidateAnalysisRequest(request AnalysisRequest) []ValidationError {
+	var errs []ValidationError
+	// The underlying price cant be negative
+	if request.UnderlyingPrice < 0 {
+		errs = append(errs, fieldError("underlying_price", CodeNegative, "underlying price must be non-negative"))
+	}
+	// The volatility cant be negative
+	if request.Volatility < 0 {
+		errs = append(errs, fieldError("volatility", CodeNegative, "volatility must be non-negative"))
+	}
+	// Check that the fill mode is correct, a missing fill mode means natural
+	if request.FillMode != "" && request.FillMode != Natural && request.FillMode != Mid && request.FillMode != Worst {
+		errs = append(errs, fieldError("fill_mode", CodeInvalid, "invalid fill mode. natural, mid or worst"))
+	}
+	// Check that the fee schedule is correct
+	if request.Fees != nil {
+		errs = append(errs, nested("fees", ValidateFeeSchedule(*request.Fees))...)
+	}
+	// The curves can only be projected forward in time
+	for i, days := range request.CurveDays {
+		if days < 0 {
+			errs = append(errs, fieldError(fmt.Sprintf("curve_days[%d]", i), CodeNegative, "curve days must be non-negative"))
+		}
+	}
+	// Contracts that are still alive at a requested evaluation date can only be valued with a volatility
+	if request.EvaluationDate != nil && !request.HasVolatility() {
+		errs = append(errs, fieldError("volatility", CodeRequired, "volatility is required to evaluate contracts before their expiration"))
+	}
+	// Check that the volatility surface is correct
+	if request.VolatilitySurface != nil {
+		errs = append(errs, nested("volatility_surface", ValidateVolatilitySurface(*request.VolatilitySurface))...)
+	}
+	// Check that the analysis context is correct and agrees with the underlying price and risk-free rate given alongside the contracts
+	if request.Context != nil {
+		errs = append(errs, nested("context", ValidateAnalysisContext(*request.Context))...)
+		if request.UnderlyingPrice != 0 && request.Context.UnderlyingPrice != 0 && request.UnderlyingPrice != request.Context.UnderlyingPrice {
+			errs = append(errs, fieldError("underlying_price", CodeConflict, "underlying price does not match the context"))
+		}
+		if request.RiskFreeRate != 0 && request.Context.RiskFreeRate != 0 && request.RiskFreeRate != request.Context.RiskFreeRate {
+			errs = append(errs, fieldError("risk_free_rate", CodeConflict, "risk-free rate does not match the context"))
+		}
+	}
+	// The paths are simulated from the underlying price with the volatility
+	if request.Simulation != nil {
+		if request.ResolveContext().UnderlyingPrice == 0 || !request.HasVolatility() {
+			errs = append(errs, fieldError("simulation", CodeRequired, "underlying price and volatility are required to simulate"))
+		}
+		errs = append(errs, nested("simulation", ValidateSimulationSettings(*request.Simulation))...)
+		// Every step of every path values every contract, so the paths and steps together are capped for the number of contracts
+		if request.Simulation.Paths <= MAX_SIMULATION_PATHS && max(1, request.Simulation.Steps) > request.Simulation.MaxSteps(request.Contracts) {
+			errs = append(errs, fieldError("simulation.steps", CodeTooLarge, "too many simulation paths and steps for the contracts"))
+		}
+	}
+	// Check that the sanity settings are correct before applying them
+	if request.Sanity != nil {
+		sanityErrs := nested("sanity", ValidateSanitySettings(*request.Sanity))
+		if len(sanityErrs) > 0 {
+			return append(errs, sanityErrs...)
+		}
+	}
+	// The quotes that break a market-sanity rule configured as an error fail the request
+	sanityErrs, _ := CheckSanity(request)
+	return append(errs, sanityErrs...)
+}
@@ -0,0 +1,10 @@
+package model
+
+// AnalysisV2 represents the second version of the analysis result.
+// It replaces the maximum profit and maximum loss strings of the first version by numbers, which are null when unbounded
+// as the metrics flag them.
+type AnalysisV2 struct {
+	Analysis
+	MaxProfit *float64 `json:"max_profit"`
+	MaxLoss   *float64 `json:"max_loss"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Clock tells the time a request is checked and valued at when it gives no valuation date
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the clock of the machine
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a clock stopped at a given time, to check and value requests as of that time
+type FixedClock time.Time
+
+// Now returns the time the clock is stopped at
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Dividend represents a cash dividend paid by the underlying
+type Dividend struct {
+	ExDate time.Time `json:"ex_date"`
+	Amount float64   `json:"amount"` // Cash paid per share
+}
+
+func IsDividendValid(dividend Dividend) error {
+	return firstError(ValidateDividend(dividend))
+}
+
+// ValidateDividend checks every field of a dividend and returns all the errors found
+func ValidateDividend(dividend Dividend) []ValidationError {
+	var errs []ValidationError
+	// The amount cant be negative
+	if dividend.Amount < 0 {
+		errs = append(errs, fieldError("amount", CodeNegative, "dividend amount must be non-negative"))
+	}
+	// The dividend has to go ex on a date
+	if dividend.ExDate.IsZero() {
+		errs = append(errs, fieldError("ex_date", CodeRequired, "dividend ex date is required"))
+	}
+	return errs
+}
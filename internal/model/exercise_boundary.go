@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// ExerciseBoundary represents the underlying prices at which an american option is best exercised early
+type ExerciseBoundary struct {
+	Type           OptionType              `json:"type"`
+	LongShort      Position                `json:"long_short"`
+	StrikePrice    float64                 `json:"strike_price"`
+	ExpirationDate time.Time               `json:"expiration_date"`
+	Boundary       []ExerciseBoundaryPoint `json:"boundary"`
+}
+
+// ExerciseBoundaryPoint represents the critical underlying price on a date, a put is exercised below it and a call above it
+type ExerciseBoundaryPoint struct {
+	Date            time.Time `json:"date"`
+	UnderlyingPrice float64   `json:"underlying_price"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/calendar"
+)
+
+// LegExpiration represents the instant a contract settles at and the sessions left to trade it from the valuation time
+type LegExpiration struct {
+	Type        OptionType          `json:"type"`
+	LongShort   Position            `json:"long_short"`
+	StrikePrice float64             `json:"strike_price"`
+	Symbol      string              `json:"symbol,omitempty"`
+	Settlement  calendar.Settlement `json:"settlement"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+	TradingDays int                 `json:"trading_days"`
+}
@@ -0,0 +1,46 @@
+package model
+
+// FeeSchedule represents the commissions and fees charged to trade a position
+type FeeSchedule struct {
+	PerContract   float64 `json:"per_contract"`    // Commission per option or futures contract traded
+	PerOrder      float64 `json:"per_order"`       // Flat commission for the whole order
+	PerLegMinimum float64 `json:"per_leg_minimum"` // Minimum commission charged on every leg
+	Assignment    float64 `json:"assignment"`      // Fee per short option contract assigned at expiry
+	Exercise      float64 `json:"exercise"`        // Fee per long option contract exercised at expiry
+}
+
+// FeeAnalysis represents the fees of a position and its results before and after them
+type FeeAnalysis struct {
+	EntryFees            float64   `json:"entry_fees"`
+	GrossMaxProfit       string    `json:"gross_max_profit"`
+	GrossMaxLoss         string    `json:"gross_max_loss"`
+	GrossBreakEvenPoints []float64 `json:"gross_break_even_points"`
+	NetMaxProfit         string    `json:"net_max_profit"`
+	NetMaxLoss           string    `json:"net_max_loss"`
+	NetBreakEvenPoints   []float64 `json:"net_break_even_points"`
+}
+
+func IsFeeScheduleValid(fees FeeSchedule) error {
+	return firstError(ValidateFeeSchedule(fees))
+}
+
+// ValidateFeeSchedule checks every fee of a schedule and returns all the errors found
+func ValidateFeeSchedule(fees FeeSchedule) []ValidationError {
+	var errs []ValidationError
+	// None of the fees can be negative
+	for _, fee := range []struct {
+		field  string
+		amount float64
+	}{
+		{"per_contract", fees.PerContract},
+		{"per_order", fees.PerOrder},
+		{"per_leg_minimum", fees.PerLegMinimum},
+		{"assignment", fees.Assignment},
+		{"exercise", fees.Exercise},
+	} {
+		if fee.amount < 0 {
+			errs = append(errs, fieldError(fee.field, CodeNegative, "fees must be non-negative"))
+		}
+	}
+	return errs
+}
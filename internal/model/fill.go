@@ -0,0 +1,17 @@
+package model
+
+type FillMode string
+
+const (
+	Natural FillMode = "natural" // Buy at the ask and sell at the bid
+	Mid     FillMode = "mid"     // Buy and sell at the midpoint of the bid and ask
+	Worst   FillMode = "worst"   // Buy at the higher and sell at the lower of the bid and ask, even when the quotes are crossed
+)
+
+type PremiumType string
+
+const (
+	Debit  PremiumType = "debit"
+	Credit PremiumType = "credit"
+	Even   PremiumType = "even"
+)
@@ -0,0 +1,47 @@
+package model
+
+// Greeks represents the sensitivities of a position's value
+type Greeks struct {
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Theta float64 `json:"theta"`
+	Vega  float64 `json:"vega"`
+	Rho   float64 `json:"rho"`
+}
+
+// LegGreeks represents the greeks of a single contract of the position
+type LegGreeks struct {
+	Type        OptionType `json:"type"`
+	LongShort   Position   `json:"long_short"`
+	StrikePrice float64    `json:"strike_price"`
+	Symbol      string     `json:"symbol,omitempty"`
+	Greeks
+}
+
+// PositionGreeks represents the greeks of every contract and of the whole position
+type PositionGreeks struct {
+	Legs  []LegGreeks `json:"legs"`
+	Total Greeks      `json:"total"`
+}
+
+// Add returns the sum of both sets of greeks
+func (g Greeks) Add(other Greeks) Greeks {
+	return Greeks{
+		Delta: g.Delta + other.Delta,
+		Gamma: g.Gamma + other.Gamma,
+		Theta: g.Theta + other.Theta,
+		Vega:  g.Vega + other.Vega,
+		Rho:   g.Rho + other.Rho,
+	}
+}
+
+// Scale returns the greeks multiplied by the given factor
+func (g Greeks) Scale(factor float64) Greeks {
+	return Greeks{
+		Delta: g.Delta * factor,
+		Gamma: g.Gamma * factor,
+		Theta: g.Theta * factor,
+		Vega:  g.Vega * factor,
+		Rho:   g.Rho * factor,
+	}
+}
@@ -0,0 +1,13 @@
+package model
+
+// LegImpliedVolatility represents the implied volatilities of a contract's bid, mid and ask.
+// A volatility is left out when the quote cannot be solved for.
+type LegImpliedVolatility struct {
+	Type        OptionType `json:"type"`
+	LongShort   Position   `json:"long_short"`
+	StrikePrice float64    `json:"strike_price"`
+	Symbol      string     `json:"symbol,omitempty"`
+	Bid         *float64   `json:"bid,omitempty"`
+	Mid         *float64   `json:"mid,omitempty"`
+	Ask         *float64   `json:"ask,omitempty"`
+}
@@ -0,0 +1,31 @@
+package model
+
+type MarginTreatment string
+
+const (
+	MarginLong       MarginTreatment = "long"       // An option paid for in full
+	MarginNaked      MarginTreatment = "naked"      // A short option without any cover
+	MarginCovered    MarginTreatment = "covered"    // A short option covered by a position in the underlying
+	MarginSpread     MarginTreatment = "spread"     // A short option covered by a long option of the same type
+	MarginUnderlying MarginTreatment = "underlying" // Shares bought or sold short on margin
+	MarginFutures    MarginTreatment = "futures"    // A futures contract, margined by its exchange rather than Reg-T
+)
+
+// MarginAnalysis represents the capital a position ties up under Reg-T strategy-based margin
+type MarginAnalysis struct {
+	Requirement       float64           `json:"requirement"`                 // Margin requirement before the proceeds of the short options are applied
+	BuyingPowerEffect float64           `json:"buying_power_effect"`         // Buying power the position uses once the proceeds are applied
+	ReturnOnCapital   *float64          `json:"return_on_capital,omitempty"` // Maximum profit over the buying power effect, left out when either is not positive and finite
+	Components        []MarginComponent `json:"components"`
+}
+
+// MarginComponent represents the requirement of some units of a contract under a given treatment, a contract split between treatments has a component for each
+type MarginComponent struct {
+	Type        OptionType      `json:"type"`
+	LongShort   Position        `json:"long_short"`
+	StrikePrice float64         `json:"strike_price"`
+	Symbol      string          `json:"symbol,omitempty"`
+	Units       float64         `json:"units"`
+	Treatment   MarginTreatment `json:"treatment"`
+	Requirement float64         `json:"requirement"`
+}
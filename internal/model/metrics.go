@@ -0,0 +1,13 @@
+package model
+
+// RiskMetrics represents the ratios derived from the maximum profit and maximum loss of the position.
+// A ratio is left out when it is undefined, such as the return on a risk that is unbounded or a width without a spread.
+type RiskMetrics struct {
+	RiskRewardRatio         *float64 `json:"risk_reward_ratio,omitempty"`           // Maximum loss over maximum profit, 0 when the profit is unbounded
+	ReturnOnMaxRisk         *float64 `json:"return_on_max_risk,omitempty"`          // Maximum profit over maximum loss, 0 when the loss is unbounded
+	Width                   *float64 `json:"width,omitempty"`                       // Widest distance between a short option and the nearest long option of its type
+	MaxProfitPercentOfWidth *float64 `json:"max_profit_percent_of_width,omitempty"` // Maximum profit as a percentage of the width
+	CreditPercentOfWidth    *float64 `json:"credit_percent_of_width,omitempty"`     // Credit received as a percentage of the width, only for a credit
+	UnboundedProfit         bool     `json:"unbounded_profit"`
+	UnboundedLoss           bool     `json:"unbounded_loss"`
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OCC_ROOT_LENGTH    = 6      // Width the root is padded to with spaces in an OCC symbol
+	OCC_STRIKE_DIGITS  = 8      // Digits of the strike in an OCC symbol, in thousandths of a dollar
+	OCC_STRIKE_DIVISOR = 1000.0 // The strike of an OCC symbol is in thousandths of a dollar
+)
+
+// occRootPattern matches the root of an OCC/OSI symbol
+var occRootPattern = regexp.MustCompile(`^[A-Z0-9.]{1,6}$`)
+
+// occPattern matches an OCC/OSI symbol, the root with or without its padding, the expiration as YYMMDD, C or P and the strike
+var occPattern = regexp.MustCompile(`^([A-Z0-9.]{1,6}) *(\d{6})([CP])(\d{8})$`)
+
+// OCCSymbol represents the parts of an OCC/OSI option symbol like `AAPL  251217C00100000`
+type OCCSymbol struct {
+	Root           string
+	ExpirationDate time.Time
+	Type           OptionType
+	StrikePrice    float64
+}
+
+// ParseOCCSymbol parses an OCC/OSI option symbol, with the root padded to 6 characters or not
+func ParseOCCSymbol(symbol string) (OCCSymbol, error) {
+	match := occPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(symbol)))
+	if match == nil {
+		return OCCSymbol{}, fmt.Errorf("invalid OCC symbol %q. root, YYMMDD, C or P and the strike in thousandths", symbol)
+	}
+	expiration, err := time.Parse("060102", match[2])
+	if err != nil {
+		return OCCSymbol{}, fmt.Errorf("invalid OCC symbol %q. expiration is not a date", symbol)
+	}
+	optionType := Call
+	if match[3] == "P" {
+		optionType = Put
+	}
+	strike, _ := strconv.Atoi(match[4])
+	return OCCSymbol{
+		Root:           match[1],
+		ExpirationDate: expiration,
+		Type:           optionType,
+		StrikePrice:    float64(strike) / OCC_STRIKE_DIVISOR,
+	}, nil
+}
+
+// String formats the OCC/OSI symbol with the root padded to 6 characters
+func (s OCCSymbol) String() string {
+	right := "C"
+	if s.Type == Put {
+		right = "P"
+	}
+	strike := int(math.Round(s.StrikePrice * OCC_STRIKE_DIVISOR))
+	return fmt.Sprintf("%-*s%s%s%0*d", OCC_ROOT_LENGTH, s.Root, s.ExpirationDate.Format("060102"), right, OCC_STRIKE_DIGITS, strike)
+}
+
+// OCCSymbol returns the OCC/OSI symbol of an option, it reports false when the contract is not an option or cannot be written as one
+func (c OptionsContract) OCCSymbol() (OCCSymbol, bool) {
+	root := strings.ToUpper(c.Root)
+	strike := math.Round(c.StrikePrice * OCC_STRIKE_DIVISOR)
+	if !c.IsOption() || !occRootPattern.MatchString(root) || c.ExpirationDate.IsZero() || strike <= 0 || strike >= math.Pow10(OCC_STRIKE_DIGITS) {
+		return OCCSymbol{}, false
+	}
+	return OCCSymbol{Root: root, ExpirationDate: c.ExpirationDate, Type: c.Type, StrikePrice: c.StrikePrice}, true
+}
+
+// ResolveSymbol returns the contract with the type, strike, expiration and root it leaves out filled in from its symbol,
+// or with the symbol of an option that gives its root but no symbol. A symbol that does not parse is left for the validation.
+func (c OptionsContract) ResolveSymbol() OptionsContract {
+	if c.Symbol == "" {
+		if symbol, ok := c.OCCSymbol(); ok {
+			c.Symbol = symbol.String()
+		}
+		return c
+	}
+	symbol, err := ParseOCCSymbol(c.Symbol)
+	if err != nil {
+		return c
+	}
+	if c.Type == "" {
+		c.Type = symbol.Type
+	}
+	if c.StrikePrice == 0 {
+		c.StrikePrice = symbol.StrikePrice
+	}
+	if c.ExpirationDate.IsZero() {
+		c.ExpirationDate = symbol.ExpirationDate
+	}
+	if c.Root == "" {
+		c.Root = symbol.Root
+	}
+	c.Symbol = symbol.String()
+	return c
+}
+
+// ResolveSymbols resolves the symbol of every contract in place
+func ResolveSymbols(contracts []OptionsContract) {
+	for i := range contracts {
+		contracts[i] = contracts[i].ResolveSymbol()
+	}
+}
+
+// ValidateSymbol checks that the symbol of a contract parses and agrees with the fields the contract gives and returns all the errors found
+func ValidateSymbol(contract OptionsContract) []ValidationError {
+	if contract.Symbol == "" {
+		// The root has to fit in a symbol
+		if contract.Root != "" && !occRootPattern.MatchString(strings.ToUpper(contract.Root)) {
+			return []ValidationError{fieldError("root", CodeInvalid, "root must be 1 to 6 letters, digits or dots")}
+		}
+		return nil
+	}
+	symbol, err := ParseOCCSymbol(contract.Symbol)
+	if err != nil {
+		return []ValidationError{fieldError("symbol", CodeInvalid, err.Error())}
+	}
+	var errs []ValidationError
+	if contract.Type != symbol.Type {
+		errs = append(errs, fieldError("type", CodeConflict, "type does not match the symbol"))
+	}
+	if math.Abs(contract.StrikePrice-symbol.StrikePrice) > 1/OCC_STRIKE_DIVISOR/2 {
+		errs = append(errs, fieldError("strike_price", CodeConflict, "strike price does not match the symbol"))
+	}
+	// The symbol only carries the day, which is read in the time zone the expiration is given in
+	year, month, day := contract.ExpirationDate.Date()
+	if year != symbol.ExpirationDate.Year() || month != symbol.ExpirationDate.Month() || day != symbol.ExpirationDate.Day() {
+		errs = append(errs, fieldError("expiration_date", CodeConflict, "expiration date does not match the symbol"))
+	}
+	if !strings.EqualFold(contract.Root, symbol.Root) {
+		errs = append(errs, fieldError("root", CodeConflict, "root does not match the symbol"))
+	}
+	return errs
+}
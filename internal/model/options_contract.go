@@ -1,15 +1,20 @@
 package model
 
 import (
-	"errors"
 	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/calendar"
 )
 
+const SHARES_PER_CONTRACT = 100 // The default contract multiplier
+
 type OptionType string
 
 const (
-	Put  OptionType = "Put"
-	Call OptionType = "Call"
+	Put    OptionType = "Put"
+	Call   OptionType = "Call"
+	Stock  OptionType = "Stock"  // Shares of the underlying bought or sold at the cost basis
+	Future OptionType = "Future" // A futures contract on the underlying traded at the cost basis
 )
 
 type Position string
@@ -19,39 +24,162 @@ const (
 	Short Position = "short"
 )
 
+type ExerciseStyle string
+
+const (
+	European ExerciseStyle = "european" // Only exercised at expiry
+	American ExerciseStyle = "american" // Exercised at any time up to expiry
+)
+
 type OptionsContract struct {
-	Type           OptionType `json:"type"`
-	LongShort      Position   `json:"long_short"`
-	StrikePrice    float64    `json:"strike_price"`
-	Bid            float64    `json:"bid"`
-	Ask            float64    `json:"ask"`
-	ExpirationDate time.Time  `json:"expiration_date"`
+	Type           OptionType          `json:"type"`
+	LongShort      Position            `json:"long_short"`
+	StrikePrice    float64             `json:"strike_price"`
+	Bid            float64             `json:"bid"`
+	Ask            float64             `json:"ask"`
+	ExpirationDate time.Time           `json:"expiration_date"`
+	Quantity       int                 `json:"quantity"`
+	Multiplier     float64             `json:"multiplier"`
+	CostBasis      float64             `json:"cost_basis"`
+	FillPrice      *float64            `json:"fill_price,omitempty"`
+	ExerciseStyle  ExerciseStyle       `json:"exercise_style,omitempty"`
+	Settlement     calendar.Settlement `json:"settlement,omitempty"`
+	Root           string              `json:"root,omitempty"`   // Symbol of the underlying the option is listed on
+	Symbol         string              `json:"symbol,omitempty"` // OCC/OSI symbol, which the type, strike, expiration and root can be left out for
+}
+
+// IsOption returns whether the contract is an option rather than a position in the underlying
+func (c OptionsContract) IsOption() bool {
+	return c.Type == Call || c.Type == Put
+}
+
+// ExpiresAt returns the instant the contract settles at, the close of the expiration date in New York or its open for AM settlement
+func (c OptionsContract) ExpiresAt() time.Time {
+	return calendar.ExpiryInstant(c.ExpirationDate, c.Settlement)
+}
+
+// ReferencePrice returns the price the contract's payoff is anchored to, the strike of an option or the cost basis of the underlying
+func (c OptionsContract) ReferencePrice() float64 {
+	if c.IsOption() {
+		return c.StrikePrice
+	}
+	return c.CostBasis
+}
+
+// ContractQuantity returns the number of contracts held, a missing quantity means a single contract
+func (c OptionsContract) ContractQuantity() int {
+	if c.Quantity == 0 {
+		return 1
+	}
+	return c.Quantity
+}
+
+// ContractMultiplier returns the units of the underlying per contract.
+// A missing multiplier means a single share for stock and the standard equity option size otherwise.
+func (c OptionsContract) ContractMultiplier() float64 {
+	if c.Multiplier == 0 && c.Type == Stock {
+		return 1
+	}
+	if c.Multiplier == 0 {
+		return SHARES_PER_CONTRACT
+	}
+	return c.Multiplier
+}
+
+// Units returns the total units of the underlying the contracts cover
+func (c OptionsContract) Units() float64 {
+	return float64(c.ContractQuantity()) * c.ContractMultiplier()
+}
+
+// HasMixedExpirations reports whether the options in a set of contracts expire on different dates
+func HasMixedExpirations(contracts []OptionsContract) bool {
+	var expiration time.Time
+	for _, contract := range contracts {
+		if !contract.IsOption() {
+			continue
+		}
+		if expiration.IsZero() {
+			expiration = contract.ExpiresAt()
+		} else if !contract.ExpiresAt().Equal(expiration) {
+			return true
+		}
+	}
+	return false
 }
 
 func IsOptionsContractValid(contract OptionsContract) error {
+	return IsOptionsContractValidAt(contract, SystemClock{}.Now())
+}
+
+// IsOptionsContractValidAt checks a contract as of the valuation time, which it cant be expired at
+func IsOptionsContractValidAt(contract OptionsContract, valuationTime time.Time) error {
+	return firstError(ValidateOptionsContract(contract, valuationTime))
+}
+
+// ValidateOptionsContract checks every field of a contract as of the valuation time and returns all the errors found
+func ValidateOptionsContract(contract OptionsContract, valuationTime time.Time) []ValidationError {
+	// Check that the symbol parses and agrees with the contract
+	errs := ValidateSymbol(contract)
 	// Check for the type being correctly set
-	if contract.Type != Call && contract.Type != Put {
-		return errors.New("invalid option type. Call or Put")
+	if !contract.IsOption() && contract.Type != Stock && contract.Type != Future {
+		errs = append(errs, fieldError("type", CodeInvalid, "invalid option type. Call, Put, Stock or Future"))
 	}
 	// Check that the contract position is correct
 	if contract.LongShort != Long && contract.LongShort != Short {
-		return errors.New("invalid position type. long or short")
+		errs = append(errs, fieldError("long_short", CodeInvalid, "invalid position type. long or short"))
 	}
 	// The strike has to be greater than 0
-	if contract.StrikePrice <= 0 {
-		return errors.New("strike price must be greater than zero")
+	if contract.IsOption() && contract.StrikePrice <= 0 {
+		errs = append(errs, fieldError("strike_price", CodeNotPositive, "strike price must be greater than zero"))
+	}
+	// The stock or future has to be bought or sold at a price greater than 0
+	if (contract.Type == Stock || contract.Type == Future) && contract.CostBasis <= 0 {
+		errs = append(errs, fieldError("cost_basis", CodeNotPositive, "cost basis must be greater than zero"))
+	}
+	// A futures contract has no standard size so its multiplier has to be given
+	if contract.Type == Future && contract.Multiplier == 0 {
+		errs = append(errs, fieldError("multiplier", CodeRequired, "multiplier is required for futures"))
+	}
+	// A futures contract always expires, unlike stock
+	if contract.Type == Future && contract.ExpirationDate.IsZero() {
+		errs = append(errs, fieldError("expiration_date", CodeRequired, "expiration date is required for futures"))
 	}
 	// The bid cant be negative
 	if contract.Bid < 0 {
-		return errors.New("bid must be non-negative")
+		errs = append(errs, fieldError("bid", CodeNegative, "bid must be non-negative"))
 	}
 	// The ask cant be negative
 	if contract.Ask < 0 {
-		return errors.New("ask must be non-negative")
+		errs = append(errs, fieldError("ask", CodeNegative, "ask must be non-negative"))
+	}
+	// The fill price cant be negative
+	if contract.FillPrice != nil && *contract.FillPrice < 0 {
+		errs = append(errs, fieldError("fill_price", CodeNegative, "fill price must be non-negative"))
+	}
+	// Check that the exercise style is correct, a missing exercise style means european
+	if contract.ExerciseStyle != "" && contract.ExerciseStyle != European && contract.ExerciseStyle != American {
+		errs = append(errs, fieldError("exercise_style", CodeInvalid, "invalid exercise style. european or american"))
+	}
+	// The quantity cant be negative, a missing quantity means a single contract
+	if contract.Quantity < 0 {
+		errs = append(errs, fieldError("quantity", CodeNegative, "quantity must be non-negative"))
+	}
+	// The multiplier cant be negative, a missing multiplier means 100 shares
+	if contract.Multiplier < 0 {
+		errs = append(errs, fieldError("multiplier", CodeNegative, "multiplier must be non-negative"))
+	}
+	// Check that the settlement is correct, a missing settlement means PM
+	if contract.Settlement != "" && contract.Settlement != calendar.PM && contract.Settlement != calendar.AM {
+		errs = append(errs, fieldError("settlement", CodeInvalid, "invalid settlement. am or pm"))
 	}
-	// The contract cant be expired
-	if contract.ExpirationDate.Before(time.Now()) {
-		return errors.New("expiration date must be in the future")
+	// The contract cant have settled by the valuation time and needs a session left to trade it in, stock never expires
+	// and a future missing its expiration date is already reported
+	if contract.Type != Stock && !(contract.Type == Future && contract.ExpirationDate.IsZero()) {
+		if contract.ExpiresAt().Before(valuationTime) {
+			errs = append(errs, fieldError("expiration_date", CodeExpired, "expiration date must be in the future"))
+		} else if calendar.TradingDaysToExpiry(valuationTime, contract.ExpiresAt()) == 0 {
+			errs = append(errs, fieldError("expiration_date", CodeExpired, "contract has no trading session left before its expiration"))
+		}
 	}
-	return nil
+	return errs
 }
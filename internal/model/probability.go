@@ -0,0 +1,9 @@
+package model
+
+// ProbabilityAnalysis represents the odds of the position under a lognormal distribution of the underlying price
+type ProbabilityAnalysis struct {
+	ProbabilityOfProfit    float64 `json:"probability_of_profit"`
+	ProbabilityOfMaxProfit float64 `json:"probability_of_max_profit"`
+	ProbabilityOfMaxLoss   float64 `json:"probability_of_max_loss"`
+	ExpectedProfitLoss     float64 `json:"expected_profit_loss"`
+}
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const DEFAULT_MAX_SPREAD_PERCENT = 50.0 // Widest bid-ask spread, as a percentage of the mid, before a quote is flagged
+
+type SanityRuleName string
+
+const (
+	RuleBidAsk         SanityRuleName = "bid_ask"         // The bid is at or below the ask
+	RuleLockedQuote    SanityRuleName = "locked_quote"    // The bid and ask are not equal
+	RuleSpreadWidth    SanityRuleName = "spread_width"    // The bid-ask spread is not wider than the max spread percent of the mid
+	RulePutPremium     SanityRuleName = "put_premium"     // A put is quoted below its strike
+	RuleIntrinsicValue SanityRuleName = "intrinsic_value" // An option is quoted at or above its intrinsic value given the spot
+	RuleDuplicateLegs  SanityRuleName = "duplicate_legs"  // No two contracts are the same
+	RuleOffsettingLegs SanityRuleName = "offsetting_legs" // No two contracts cancel each other
+)
+
+type RuleSeverity string
+
+const (
+	SeverityError   RuleSeverity = "error"   // A broken rule fails the request
+	SeverityWarning RuleSeverity = "warning" // A broken rule is returned along with the analysis
+	SeverityOff     RuleSeverity = "off"     // The rule is not checked
+)
+
+// SanitySettings represents how the market-sanity rules are applied to a request
+type SanitySettings struct {
+	Rules            map[SanityRuleName]RuleSeverity `json:"rules"`              // Severity of the rules, their default severity when left out
+	MaxSpreadPercent float64                         `json:"max_spread_percent"` // DEFAULT_MAX_SPREAD_PERCENT when zero
+}
+
+// SanityRule represents a market-sanity check of the contracts of a request, the errors it returns are reported with the severity of the rule
+type SanityRule struct {
+	Name     SanityRuleName
+	Severity RuleSeverity // Severity when the request does not configure the rule
+	Check    func(request AnalysisRequest, settings SanitySettings) []ValidationError
+}
+
+// DefaultSanityRules returns the market-sanity rules checked on every request, only a crossed quote fails it by default
+func DefaultSanityRules() []SanityRule {
+	return []SanityRule{
+		{Name: RuleBidAsk, Severity: SeverityError, Check: checkBidAsk},
+		{Name: RuleLockedQuote, Severity: SeverityWarning, Check: checkLockedQuote},
+		{Name: RuleSpreadWidth, Severity: SeverityWarning, Check: checkSpreadWidth},
+		{Name: RulePutPremium, Severity: SeverityWarning, Check: checkPutPremium},
+		{Name: RuleIntrinsicValue, Severity: SeverityWarning, Check: checkIntrinsicValue},
+		{Name: RuleDuplicateLegs, Severity: SeverityWarning, Check: checkDuplicateLegs},
+		{Name: RuleOffsettingLegs, Severity: SeverityWarning, Check: checkOffsettingLegs},
+	}
+}
+
+// CheckSanity checks the contracts of a request against the default market-sanity rules
+func CheckSanity(request AnalysisRequest) (errs, warnings []ValidationError) {
+	return CheckSanityRules(DefaultSanityRules(), request)
+}
+
+// CheckSanityRules checks the contracts of a request against the rules and splits what they find by the severity the request gives them
+func CheckSanityRules(rules []SanityRule, request AnalysisRequest) (errs, warnings []ValidationError) {
+	var settings SanitySettings
+	if request.Sanity != nil {
+		settings = *request.Sanity
+	}
+	for _, rule := range rules {
+		severity := rule.Severity
+		if configured, ok := settings.Rules[rule.Name]; ok {
+			severity = configured
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		for _, err := range rule.Check(request, settings) {
+			err.Rule = rule.Name
+			if severity == SeverityError {
+				errs = append(errs, err)
+			} else {
+				warnings = append(warnings, err)
+			}
+		}
+	}
+	return errs, warnings
+}
+
+// ValidateSanitySettings checks every field of the sanity settings and returns all the errors found
+func ValidateSanitySettings(settings SanitySettings) []ValidationError {
+	var errs []ValidationError
+	known := map[SanityRuleName]bool{}
+	for _, rule := range DefaultSanityRules() {
+		known[rule.Name] = true
+	}
+	// Sort the rules so the errors come back in the same order every time
+	names := make([]string, 0, len(settings.Rules))
+	for name := range settings.Rules {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := "rules." + name
+		if !known[SanityRuleName(name)] {
+			errs = append(errs, fieldError(field, CodeInvalid, fmt.Sprintf("unknown sanity rule %s", name)))
+		}
+		severity := settings.Rules[SanityRuleName(name)]
+		if severity != SeverityError && severity != SeverityWarning && severity != SeverityOff {
+			errs = append(errs, fieldError(field, CodeInvalid, "invalid rule severity. error, warning or off"))
+		}
+	}
+	// The max spread percent cant be negative, a missing one means the default
+	if settings.MaxSpreadPercent < 0 {
+		errs = append(errs, fieldError("max_spread_percent", CodeNegative, "max spread percent must be non-negative"))
+	}
+	return errs
+}
+
+// checkBidAsk flags the quotes whose bid is above the ask, a negative ask is already invalid
+func checkBidAsk(request AnalysisRequest, _ SanitySettings) []ValidationError {
+	var errs []ValidationError
+	for i, contract := range request.Contracts {
+		if contract.Ask >= 0 && contract.Bid > contract.Ask {
+			errs = append(errs, legError(i, "bid", fmt.Sprintf("bid %.2f is above the ask %.2f", contract.Bid, contract.Ask)))
+		}
+	}
+	return errs
+}
+
+// checkLockedQuote flags the quotes whose bid and ask are equal, a missing quote is not checked
+func checkLockedQuote(request AnalysisRequest, _ SanitySettings) []ValidationError {
+	var errs []ValidationError
+	for i, contract := range request.Contracts {
+		if contract.Ask > 0 && contract.Bid == contract.Ask {
+			errs = append(errs, legError(i, "ask", fmt.Sprintf("bid and ask are both %.2f", contract.Ask)))
+		}
+	}
+	return errs
+}
+
+// checkSpreadWidth flags the option quotes whose bid-ask spread is wider than the max spread percent of the mid
+func checkSpreadWidth(request AnalysisRequest, settings SanitySettings) []ValidationError {
+	maxPercent := settings.MaxSpreadPercent
+	if maxPercent == 0 {
+		maxPercent = DEFAULT_MAX_SPREAD_PERCENT
+	}
+	var errs []ValidationError
+	for i, contract := range request.Contracts {
+		mid := (contract.Bid + contract.Ask) / 2
+		if !contract.IsOption() || mid <= 0 || contract.Bid > contract.Ask {
+			continue
+		}
+		if percent := (contract.Ask - contract.Bid) / mid * 100; percent > maxPercent {
+			errs = append(errs, legError(i, "ask", fmt.Sprintf("bid-ask spread is %.2f%% of the mid, above %.2f%%", percent, maxPercent)))
+		}
+	}
+	return errs
+}
+
+// checkPutPremium flags the puts quoted at or above their strike, which is the most a put can ever pay
+func checkPutPremium(request AnalysisRequest, _ SanitySettings) []ValidationError {
+	var errs []ValidationError
+	for i, contract := range request.Contracts {
+		if contract.Type == Put && contract.StrikePrice > 0 && contract.Ask >= contract.StrikePrice {
+			errs = append(errs, legError(i, "ask", fmt.Sprintf("put premium %.2f is not below the strike %.2f", contract.Ask, contract.StrikePrice)))
+		}
+	}
+	return errs
+}
+
+// checkIntrinsicValue flags the options whose ask is below their intrinsic value at the spot, which could be bought and exercised for a profit.
+// Without a spot there is nothing to check.
+func checkIntrinsicValue(request AnalysisRequest, _ SanitySettings) []ValidationError {
+	spot := request.ResolveContext().UnderlyingPrice
+	if spot <= 0 {
+		return nil
+	}
+	var errs []ValidationError
+	for i, contract := range request.Contracts {
+		if !contract.IsOption() || contract.Ask <= 0 {
+			continue
+		}
+		intrinsic := math.Max(0, spot-contract.StrikePrice)
+		if contract.Type == Put {
+			intrinsic = math.Max(0, contract.StrikePrice-spot)
+		}
+		if contract.Ask < intrinsic {
+			errs = append(errs, legError(i, "ask", fmt.Sprintf("ask %.2f is below the intrinsic value %.2f", contract.Ask, intrinsic)))
+		}
+	}
+	return errs
+}
+
+// checkDuplicateLegs flags the contracts that repeat an earlier one, which are better given as a single contract with a quantity
+func checkDuplicateLegs(request AnalysisRequest, _ SanitySettings) []ValidationError {
+	var errs []ValidationError
+	for i, contract := range request.Contracts {
+		for j := 0; j < i; j++ {
+			if sameInstrument(contract, request.Contracts[j]) && contract.LongShort == request.Contracts[j].LongShort {
+				errs = append(errs, legError(i, "", fmt.Sprintf("contract duplicates contract %d", j)))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// checkOffsettingLegs flags the contracts that are bought and sold at once, which cancel each other
+func checkOffsettingLegs(request AnalysisRequest, _ SanitySettings) []ValidationError {
+	var errs []ValidationError
+	for i, contract := range request.Contracts {
+		for j := 0; j < i; j++ {
+			if sameInstrument(contract, request.Contracts[j]) && contract.LongShort != request.Contracts[j].LongShort {
+				errs = append(errs, legError(i, "long_short", fmt.Sprintf("contract offsets contract %d", j)))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// sameInstrument returns whether two contracts are on the same instrument, whichever way they are held
+func sameInstrument(a, b OptionsContract) bool {
+	return a.Type == b.Type &&
+		a.ReferencePrice() == b.ReferencePrice() &&
+		a.ContractMultiplier() == b.ContractMultiplier() &&
+		(a.Type == Stock || a.ExpiresAt().Equal(b.ExpiresAt()))
+}
+
+// legError builds the market-sanity error of a field of a contract
+func legError(leg int, field string, message string) ValidationError {
+	path := fmt.Sprintf("contracts[%d]", leg)
+	if field != "" {
+		path += "." + field
+	}
+	return ValidationError{Leg: &leg, Field: path, Code: CodeImplausible, Message: message}
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const MAX_SCENARIO_AXIS_VALUES = 50 // Upper bound on the values of every axis of a scenario grid
+
+// ScenarioRequest represents the data structure of a scenario request, the contracts and market of an analysis request plus the axes of the grid.
+// Unlike an analysis request it is always an object, a bare array of contracts has no volatility or underlying price to build scenarios from.
+type ScenarioRequest struct {
+	Contracts         []OptionsContract  `json:"contracts"`
+	UnderlyingPrice   float64            `json:"underlying_price"`
+	Volatility        float64            `json:"volatility"`
+	RiskFreeRate      float64            `json:"risk_free_rate"`
+	FillMode          FillMode           `json:"fill_mode"`
+	Context           *AnalysisContext   `json:"context,omitempty"`
+	VolatilitySurface *VolatilitySurface `json:"volatility_surface,omitempty"`
+	Sanity            *SanitySettings    `json:"sanity,omitempty"`
+	ValuationDate     *time.Time         `json:"valuation_date,omitempty"`
+	Axes              ScenarioAxes       `json:"axes"`
+}
+
+// ScenarioAxes represents the axes a scenario grid spans
+type ScenarioAxes struct {
+	StandardDeviations []float64 `json:"standard_deviations"` // Moves of the underlying in standard deviations until the nearest expiration, -3 to 3 by default
+	DaysForward        []int     `json:"days_forward"`        // Days forward from the valuation date, the valuation date by default
+	VolatilityShocks   []float64 `json:"volatility_shocks"`   // Shifts added to every volatility, none by default
+}
+
+// ScenarioGrid represents the profit/loss of the contracts in every scenario, with a last date slice at the final expiration
+type ScenarioGrid struct {
+	UnderlyingPrices []ScenarioMove    `json:"underlying_prices"`
+	Dates            []ScenarioDate    `json:"dates"`
+	VolatilityShocks []float64         `json:"volatility_shocks"`
+	ProfitLoss       [][][]float64     `json:"profit_loss"`        // Indexed by date, volatility shock and underlying price
+	Warnings         []ValidationError `json:"warnings,omitempty"` // Market-sanity rules the contracts break that do not fail the request
+}
+
+// ScenarioMove represents a move of the underlying along the price axis of a scenario grid
+type ScenarioMove struct {
+	StandardDeviations float64 `json:"standard_deviations"`
+	UnderlyingPrice    float64 `json:"underlying_price"`
+}
+
+// ScenarioDate represents a valuation date along the time axis of a scenario grid
+type ScenarioDate struct {
+	DaysForward   int       `json:"days_forward"`
+	ValuationDate time.Time `json:"valuation_date"`
+	Expiry        bool      `json:"expiry"`
+}
+
+// AnalysisRequest returns the analysis request of the contracts and market of the scenarios
+func (r ScenarioRequest) AnalysisRequest() AnalysisRequest {
+	return AnalysisRequest{
+		Contracts:         r.Contracts,
+		UnderlyingPrice:   r.UnderlyingPrice,
+		Volatility:        r.Volatility,
+		RiskFreeRate:      r.RiskFreeRate,
+		FillMode:          r.FillMode,
+		Context:           r.Context,
+		VolatilitySurface: r.VolatilitySurface,
+		Sanity:            r.Sanity,
+		ValuationDate:     r.ValuationDate,
+	}
+}
+
+func IsScenarioRequestValid(request ScenarioRequest) error {
+	return firstError(ValidateScenarioRequest(request))
+}
+
+// ValidateScenarioRequest checks every model input and axis of a scenario request and returns all the errors found, the contracts are checked by ValidateContracts
+func ValidateScenarioRequest(request ScenarioRequest) []ValidationError {
+	// The contracts and market are checked like those of an analysis
+	analysisRequest := request.AnalysisRequest()
+	errs := ValidateAnalysisRequest(analysisRequest)
+	// The scenarios are priced with a model around the underlying price
+	if !analysisRequest.HasVolatility() {
+		errs = append(errs, fieldError("volatility", CodeRequired, "volatility is required to build scenarios"))
+	}
+	if analysisRequest.ResolveContext().UnderlyingPrice == 0 {
+		errs = append(errs, fieldError("underlying_price", CodeRequired, "underlying price is required to build scenarios"))
+	}
+	// The axes are capped to keep the grid small
+	for _, axis := range []struct {
+		field  string
+		values int
+	}{
+		{"axes.standard_deviations", len(request.Axes.StandardDeviations)},
+		{"axes.days_forward", len(request.Axes.DaysForward)},
+		{"axes.volatility_shocks", len(request.Axes.VolatilityShocks)},
+	} {
+		if axis.values > MAX_SCENARIO_AXIS_VALUES {
+			errs = append(errs, fieldError(axis.field, CodeTooMany, "scenario axes must have at most 50 values"))
+		}
+	}
+	// The scenarios can only be projected forward in time
+	for i, days := range request.Axes.DaysForward {
+		if days < 0 {
+			errs = append(errs, fieldError(fmt.Sprintf("axes.days_forward[%d]", i), CodeNegative, "scenario days must be non-negative"))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,100 @@
+package model
+
+const (
+	DEFAULT_SIMULATION_PATHS      = 10000    // Number of paths simulated when none is requested
+	MAX_SIMULATION_PATHS          = 50000    // Upper bound on the paths of a single simulation
+	MAX_SIMULATION_STEPS          = 250      // Upper bound on the steps of every simulated path
+	MAX_SIMULATION_VALUATIONS     = 20000000 // Upper bound on the paths times steps times contracts valued by a single simulation
+	MAX_SIMULATION_AMERICAN_STEPS = 250      // Upper bound on the steps times american contracts, every one of which is tabulated on a grid of binomial trees
+)
+
+// SimulationSettings represents the settings of a Monte Carlo simulation of the position
+type SimulationSettings struct {
+	Paths          int     `json:"paths"`           // Number of simulated paths, 10000 by default
+	Steps          int     `json:"steps"`           // Number of steps of every path, one per session by default
+	Seed           *int64  `json:"seed"`            // Seed of the random numbers, a random one when left out
+	ProfitTarget   float64 `json:"profit_target"`   // Fraction of the maximum profit the position is closed at
+	StopLoss       float64 `json:"stop_loss"`       // Fraction of the maximum loss the position is closed at
+	JumpIntensity  float64 `json:"jump_intensity"`  // Expected number of jumps of the underlying per year
+	JumpMean       float64 `json:"jump_mean"`       // Mean of the log size of a jump
+	JumpVolatility float64 `json:"jump_volatility"` // Standard deviation of the log size of a jump
+}
+
+// SimulationAnalysis represents the results of a Monte Carlo simulation of the position
+type SimulationAnalysis struct {
+	Paths                     int                    `json:"paths"`
+	Steps                     int                    `json:"steps"`
+	Seed                      int64                  `json:"seed"`
+	BreakEvenTouches          []BreakEvenTouch       `json:"break_even_touches"`
+	ProbabilityOfProfitTarget float64                `json:"probability_of_profit_target"`
+	ProbabilityOfStopLoss     float64                `json:"probability_of_stop_loss"`
+	Percentiles               []ProfitLossPercentile `json:"percentiles"`
+}
+
+// BreakEvenTouch represents the probability of the underlying touching a break-even point before expiry
+type BreakEvenTouch struct {
+	BreakEvenPoint float64 `json:"break_even_point"`
+	Probability    float64 `json:"probability"`
+}
+
+// ProfitLossPercentile represents a percentile of the simulated profit/loss when the position is closed
+type ProfitLossPercentile struct {
+	Percentile int     `json:"percentile"`
+	ProfitLoss float64 `json:"profit_loss"`
+}
+
+// MaxSteps returns the most steps a simulation of the contracts can take while keeping within the budgets of a single simulation
+func (s SimulationSettings) MaxSteps(contracts []OptionsContract) int {
+	paths := s.Paths
+	if paths == 0 {
+		paths = DEFAULT_SIMULATION_PATHS
+	}
+	steps := min(MAX_SIMULATION_STEPS, MAX_SIMULATION_VALUATIONS/max(1, paths*len(contracts)))
+	american := 0
+	for _, contract := range contracts {
+		if contract.IsOption() && contract.ExerciseStyle == American {
+			american++
+		}
+	}
+	if american > 0 {
+		steps = min(steps, MAX_SIMULATION_AMERICAN_STEPS/american)
+	}
+	return steps
+}
+
+func IsSimulationSettingsValid(settings SimulationSettings) error {
+	return firstError(ValidateSimulationSettings(settings))
+}
+
+// ValidateSimulationSettings checks every simulation setting and returns all the errors found
+func ValidateSimulationSettings(settings SimulationSettings) []ValidationError {
+	var errs []ValidationError
+	// The number of paths and steps cant be negative and are capped to keep the simulation fast
+	if settings.Paths < 0 {
+		errs = append(errs, fieldError("paths", CodeNegative, "simulation paths and steps must be non-negative"))
+	}
+	if settings.Steps < 0 {
+		errs = append(errs, fieldError("steps", CodeNegative, "simulation paths and steps must be non-negative"))
+	}
+	if settings.Paths > MAX_SIMULATION_PATHS {
+		errs = append(errs, fieldError("paths", CodeTooLarge, "simulation paths must be at most 50000"))
+	}
+	if settings.Steps > MAX_SIMULATION_STEPS {
+		errs = append(errs, fieldError("steps", CodeTooLarge, "simulation steps must be at most 250"))
+	}
+	// The targets are fractions of the extremes
+	if settings.ProfitTarget < 0 {
+		errs = append(errs, fieldError("profit_target", CodeNegative, "profit target and stop loss must be non-negative"))
+	}
+	if settings.StopLoss < 0 {
+		errs = append(errs, fieldError("stop_loss", CodeNegative, "profit target and stop loss must be non-negative"))
+	}
+	// Only the jump mean can be negative
+	if settings.JumpIntensity < 0 {
+		errs = append(errs, fieldError("jump_intensity", CodeNegative, "jump intensity and volatility must be non-negative"))
+	}
+	if settings.JumpVolatility < 0 {
+		errs = append(errs, fieldError("jump_volatility", CodeNegative, "jump intensity and volatility must be non-negative"))
+	}
+	return errs
+}
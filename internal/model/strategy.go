@@ -0,0 +1,16 @@
+package model
+
+type Bias string
+
+const (
+	Bullish Bias = "bullish"
+	Bearish Bias = "bearish"
+	Neutral Bias = "neutral"
+)
+
+// Strategy represents the recognized strategy of a set of contracts
+type Strategy struct {
+	Name        string      `json:"name"`
+	Bias        Bias        `json:"bias"`
+	PremiumType PremiumType `json:"premium_type"`
+}
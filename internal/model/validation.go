@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ValidationCode string
+
+const (
+	CodeRequired    ValidationCode = "required"     // A required field is missing
+	CodeInvalid     ValidationCode = "invalid"      // A field is not one of the accepted values
+	CodeNegative    ValidationCode = "negative"     // A field that cant be negative is
+	CodeNotPositive ValidationCode = "not_positive" // A field that has to be greater than zero is not
+	CodeTooLarge    ValidationCode = "too_large"    // A field is above its upper bound
+	CodeExpired     ValidationCode = "expired"      // A date that has to be in the future is not
+	CodeConflict    ValidationCode = "conflict"     // Fields that cannot be given together are
+	CodeTooMany     ValidationCode = "too_many"     // A list holds more items than accepted
+	CodeEmpty       ValidationCode = "empty"        // A list that needs items has none
+	CodeMalformed   ValidationCode = "malformed"    // The body cannot be decoded into the request
+	CodeImplausible ValidationCode = "implausible"  // A quote or contract breaks a market-sanity rule
+)
+
+// ValidationError represents a failed check of a request field, located by its JSON path
+type ValidationError struct {
+	Leg     *int           `json:"leg,omitempty"` // Index of the contract the field belongs to
+	Field   string         `json:"field"`
+	Code    ValidationCode `json:"code"`
+	Rule    SanityRuleName `json:"rule,omitempty"` // Market-sanity rule the contracts break
+	Message string         `json:"message"`
+}
+
+// Error returns the message of the validation error
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// Problem represents an RFC 7807 problem details body
+type Problem struct {
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+// NewValidationProblem builds the problem of a request that failed validation, its detail lists every message
+func NewValidationProblem(status int, errs []ValidationError) Problem {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Message)
+	}
+	return Problem{
+		Type:   "about:blank",
+		Title:  "Invalid request",
+		Status: status,
+		Detail: strings.Join(messages, "; "),
+		Errors: errs,
+	}
+}
+
+// ValidateContracts checks the number of contracts of a request against the cap and every contract as of the valuation time,
+// the errors of a contract are located under its index
+func ValidateContracts(contracts []OptionsContract, maxLegs int, valuationTime time.Time) []ValidationError {
+	var errs []ValidationError
+	if len(contracts) > maxLegs {
+		errs = append(errs, fieldError("contracts", CodeTooMany, fmt.Sprintf("only accepting at most %d options contracts", maxLegs)))
+	}
+	if len(contracts) == 0 {
+		errs = append(errs, fieldError("contracts", CodeEmpty, "need at least one options contracts"))
+	}
+	for i, contract := range contracts {
+		for _, err := range nested(fmt.Sprintf("contracts[%d]", i), ValidateOptionsContract(contract, valuationTime)) {
+			leg := i
+			err.Leg = &leg
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// fieldError builds the validation error of a field
+func fieldError(field string, code ValidationCode, message string) ValidationError {
+	return ValidationError{Field: field, Code: code, Message: message}
+}
+
+// nested locates the validation errors of a nested object under its path
+func nested(path string, errs []ValidationError) []ValidationError {
+	for i := range errs {
+		if errs[i].Field == "" {
+			errs[i].Field = path
+		} else {
+			errs[i].Field = path + "." + errs[i].Field
+		}
+	}
+	return errs
+}
+
+// firstError returns the first of the validation errors, or nil when there are none
+func firstError(errs []ValidationError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// VolatilitySurface represents the implied volatility across strikes and expirations,
+// either as a grid of quoted points or as SVI parameters per expiration
+type VolatilitySurface struct {
+	Points []VolatilityPoint `json:"points,omitempty"`
+	SVI    []SVISlice        `json:"svi,omitempty"`
+}
+
+// VolatilityPoint represents the implied volatility quoted at a strike and expiration
+type VolatilityPoint struct {
+	StrikePrice    float64   `json:"strike_price"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	Volatility     float64   `json:"volatility"`
+}
+
+// SVISlice represents the raw SVI parameters of an expiration, the total variance at log-moneyness k = ln(strike/forward)
+// is a + b * (rho * (k - m) + sqrt((k - m)^2 + sigma^2))
+type SVISlice struct {
+	ExpirationDate time.Time `json:"expiration_date"`
+	Forward        float64   `json:"forward"`
+	A              float64   `json:"a"`
+	B              float64   `json:"b"`
+	Rho            float64   `json:"rho"`
+	M              float64   `json:"m"`
+	Sigma          float64   `json:"sigma"`
+}
+
+func IsVolatilitySurfaceValid(surface VolatilitySurface) error {
+	return firstError(ValidateVolatilitySurface(surface))
+}
+
+// ValidateVolatilitySurface checks every point or slice of a volatility surface and returns all the errors found
+func ValidateVolatilitySurface(surface VolatilitySurface) []ValidationError {
+	var errs []ValidationError
+	// The surface is given in exactly one of the two forms
+	if (len(surface.Points) == 0) == (len(surface.SVI) == 0) {
+		errs = append(errs, fieldError("", CodeConflict, "volatility surface needs either points or svi slices"))
+	}
+	// Every point needs a strike and expiration and a positive volatility, the underlying cant be spread without one
+	for i, point := range surface.Points {
+		path := fmt.Sprintf("points[%d]", i)
+		if point.StrikePrice <= 0 || point.ExpirationDate.IsZero() {
+			errs = append(errs, fieldError(path, CodeRequired, "volatility points need a strike price and expiration date"))
+		}
+		if point.Volatility <= 0 {
+			errs = append(errs, fieldError(path+".volatility", CodeNotPositive, "surface volatility must be positive"))
+		}
+	}
+	// Every slice needs a forward and parameters that keep the total variance positive
+	for i, slice := range surface.SVI {
+		path := fmt.Sprintf("svi[%d]", i)
+		if slice.Forward <= 0 || slice.ExpirationDate.IsZero() {
+			errs = append(errs, fieldError(path, CodeRequired, "svi slices need a forward and expiration date"))
+		}
+		if slice.B < 0 || math.Abs(slice.Rho) >= 1 || slice.Sigma <= 0 || slice.A+slice.B*slice.Sigma*math.Sqrt(1-slice.Rho*slice.Rho) <= 0 {
+			errs = append(errs, fieldError(path, CodeInvalid, "invalid svi parameters"))
+		}
+	}
+	return errs
+}
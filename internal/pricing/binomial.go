@@ -0,0 +1,116 @@
+package pricing
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+const (
+	BINOMIAL_STEPS      = 100  // Number of time steps of the binomial tree
+	MIN_TREE_VOLATILITY = 1e-4 // The tree needs some volatility to branch out
+)
+
+// ScheduledDividend represents a cash dividend going ex a number of years from the valuation time
+type ScheduledDividend struct {
+	Years  float64
+	Amount float64
+}
+
+// BoundaryPoint represents the critical underlying price a number of years from the valuation time
+type BoundaryPoint struct {
+	Years           float64
+	UnderlyingPrice float64
+}
+
+// ScheduleDividends returns the dividends going ex after the valuation time and up to the horizon, the trading time until they do.
+// A dividend going ex at the next open is still ahead although there is no trading time left until it
+func ScheduleDividends(dividends []model.Dividend, at, horizon time.Time) []ScheduledDividend {
+	var scheduled []ScheduledDividend
+	for _, dividend := range dividends {
+		if dividend.ExDate.After(at) && !dividend.ExDate.After(horizon) {
+			scheduled = append(scheduled, ScheduledDividend{Years: YearsBetween(at, dividend.ExDate), Amount: dividend.Amount})
+		}
+	}
+	return scheduled
+}
+
+// DividendsPresentValue calculates the value a number of years from the valuation time of the dividends going ex after it
+func DividendsPresentValue(dividends []ScheduledDividend, years, rate float64) float64 {
+	value := 0.0
+	for _, dividend := range dividends {
+		if dividend.Years >= years {
+			value += dividend.Amount * math.Exp(-rate*(dividend.Years-years))
+		}
+	}
+	return value
+}
+
+// BinomialTree calculates the per-share value of an option on a Cox-Ross-Rubinstein tree, exercising early when it pays for an american option.
+// The discrete dividends are escrowed out of the spot so the tree recombines.
+func BinomialTree(optionType model.OptionType, american bool, spot, strike, years, rate, yield, volatility float64, dividends []ScheduledDividend) float64 {
+	value, _ := binomialTree(optionType, american, spot, strike, years, rate, yield, volatility, dividends)
+	return value
+}
+
+// EarlyExerciseBoundary calculates the critical underlying price of an american option at every step of the tree where early exercise pays
+func EarlyExerciseBoundary(optionType model.OptionType, spot, strike, years, rate, yield, volatility float64, dividends []ScheduledDividend) []BoundaryPoint {
+	_, boundary := binomialTree(optionType, true, spot, strike, years, rate, yield, volatility, dividends)
+	return boundary
+}
+
+// binomialTree rolls the option value back through the tree and records the exercise boundary along the way
+func binomialTree(optionType model.OptionType, american bool, spot, strike, years, rate, yield, volatility float64, dividends []ScheduledDividend) (float64, []BoundaryPoint) {
+	// At expiry the option is only worth its intrinsic value
+	if years <= 0 {
+		return intrinsicValue(optionType, spot, strike), nil
+	}
+	volatility = math.Max(volatility, MIN_TREE_VOLATILITY)
+
+	dt := years / BINOMIAL_STEPS
+	up := math.Exp(volatility * math.Sqrt(dt))
+	growth := math.Exp((rate - yield) * dt)
+	discount := math.Exp(-rate * dt)
+	probability := (growth - 1/up) / (up - 1/up)
+
+	// The tree moves the spot net of the dividends, which are added back to get the price of the underlying at a node
+	base := EscrowedSpot(spot, dividends, rate)
+	values := make([]float64, BINOMIAL_STEPS+1)
+	for i := range values {
+		values[i] = intrinsicValue(optionType, base*math.Pow(up, float64(2*i-BINOMIAL_STEPS)), strike)
+	}
+
+	var boundary []BoundaryPoint
+	for step := BINOMIAL_STEPS - 1; step >= 0; step-- {
+		t := float64(step) * dt
+		pending := DividendsPresentValue(dividends, t, rate)
+
+		// Walk the nodes from the lowest price up, i counts the up moves
+		critical := math.NaN()
+		price := base * math.Pow(up, float64(-step))
+		for i := 0; i <= step; i++ {
+			values[i] = (probability*values[i+1] + (1-probability)*values[i]) * discount
+			if american {
+				exercise := intrinsicValue(optionType, price+pending, strike)
+				if exercise > values[i] && exercise > 0 {
+					values[i] = exercise
+					// A put is exercised up to the highest such price and a call from the lowest one
+					if optionType == model.Put || math.IsNaN(critical) {
+						critical = price + pending
+					}
+				}
+			}
+			price *= up * up
+		}
+		if !math.IsNaN(critical) && step > 0 {
+			boundary = append(boundary, BoundaryPoint{Years: t, UnderlyingPrice: critical})
+		}
+	}
+
+	// The boundary was recorded backwards in time
+	for i, j := 0, len(boundary)-1; i < j; i, j = i+1, j-1 {
+		boundary[i], boundary[j] = boundary[j], boundary[i]
+	}
+	return values[0], boundary
+}
@@ -0,0 +1,116 @@
+package pricing
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/calendar"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+const TRADING_DAYS_PER_YEAR = 252.0 // Sessions in a year of trading time, the clock every model runs on
+
+// NormCDF calculates the standard normal cumulative distribution function at x
+func NormCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// NormPDF calculates the standard normal probability density function at x
+func NormPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+// YearsToExpiry calculates the trading time in years from the valuation time until the instant the contract settles at, like every other horizon.
+// An expired contract has no time value left
+func YearsToExpiry(contract model.OptionsContract, at time.Time) float64 {
+	return YearsBetween(at, contract.ExpiresAt())
+}
+
+// YearsBetween measures the time from one instant to another in years of trading sessions, none when the second is not after the first
+func YearsBetween(from, to time.Time) float64 {
+	return calendar.TradingTime(from, to) / TRADING_DAYS_PER_YEAR
+}
+
+// DateAfter returns the instant a number of years of trading sessions after another
+func DateAfter(from time.Time, years float64) time.Time {
+	return calendar.AddTradingTime(from, years*TRADING_DAYS_PER_YEAR)
+}
+
+// BlackScholes calculates the per-share Black-Scholes value of a European option
+func BlackScholes(optionType model.OptionType, spot, strike, years, rate, volatility float64) float64 {
+	return BlackScholesMerton(optionType, spot, strike, years, rate, 0, volatility)
+}
+
+// BlackScholesMerton calculates the per-share Black-Scholes value of a European option on an underlying paying a continuous yield
+func BlackScholesMerton(optionType model.OptionType, spot, strike, years, rate, yield, volatility float64) float64 {
+	// At expiry the option is only worth its intrinsic value
+	if years <= 0 {
+		return intrinsicValue(optionType, spot, strike)
+	}
+	discountedStrike := strike * math.Exp(-rate*years)
+	discountedSpot := spot * math.Exp(-yield*years)
+
+	// Without volatility the option is worth its intrinsic value between the discounted spot and strike
+	if volatility <= 0 {
+		return intrinsicValue(optionType, discountedSpot, discountedStrike)
+	}
+
+	d1, d2 := calculateD1D2(spot, strike, years, rate, yield, volatility)
+	if optionType == model.Put {
+		return discountedStrike*NormCDF(-d2) - discountedSpot*NormCDF(-d1)
+	}
+	return discountedSpot*NormCDF(d1) - discountedStrike*NormCDF(d2)
+}
+
+// ContractValue calculates the per-share theoretical value of an options contract at the given valuation time.
+// American options are valued on a binomial tree and european ones with Black-Scholes on the spot net of the dividends.
+func ContractValue(contract model.OptionsContract, spot float64, market Market, at time.Time) float64 {
+	return NewValuation(contract, market, at).Value(spot)
+}
+
+// Valuation represents everything a contract is valued with at a valuation time besides the spot, worked out once to value it at many spots
+type Valuation struct {
+	contract   model.OptionsContract
+	years      float64
+	volatility float64
+	dividends  []ScheduledDividend
+	carry      model.Carry
+}
+
+// NewValuation prepares the valuation of a contract at the given valuation time
+func NewValuation(contract model.OptionsContract, market Market, at time.Time) Valuation {
+	valuation := Valuation{contract: contract, carry: market.Carry}
+	if contract.IsOption() {
+		valuation.years = YearsToExpiry(contract, at)
+		valuation.volatility = market.LegVolatility(contract, at)
+		valuation.dividends = ScheduleDividends(market.Dividends, at, contract.ExpiresAt())
+	}
+	return valuation
+}
+
+// Value calculates the per-share theoretical value of the contract at a spot
+func (v Valuation) Value(spot float64) float64 {
+	// A position in the underlying moves one for one with the spot
+	if !v.contract.IsOption() {
+		return spot
+	}
+	if v.contract.ExerciseStyle == model.American {
+		return BinomialTree(v.contract.Type, true, spot, v.contract.StrikePrice, v.years, v.carry.RiskFreeRate, v.carry.DividendYield, v.volatility, v.dividends)
+	}
+	return BlackScholesMerton(v.contract.Type, EscrowedSpot(spot, v.dividends, v.carry.RiskFreeRate), v.contract.StrikePrice, v.years, v.carry.RiskFreeRate, v.carry.DividendYield, v.volatility)
+}
+
+// calculateD1D2 calculates the d1 and d2 terms of the Black-Scholes formula
+func calculateD1D2(spot, strike, years, rate, yield, volatility float64) (float64, float64) {
+	volSqrtT := volatility * math.Sqrt(years)
+	d1 := (math.Log(spot/strike) + (rate-yield+volatility*volatility/2)*years) / volSqrtT
+	return d1, d1 - volSqrtT
+}
+
+// intrinsicValue calculates the value of an option if it were exercised immediately
+func intrinsicValue(optionType model.OptionType, spot, strike float64) float64 {
+	if optionType == model.Put {
+		return math.Max(0, strike-spot)
+	}
+	return math.Max(0, spot-strike)
+}
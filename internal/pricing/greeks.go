@@ -0,0 +1,95 @@
+package pricing
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+// CalculateGreeks calculates the per-share Black-Scholes greeks of a European option.
+// Theta is per trading day, vega per volatility point and rho per percentage point of rate.
+func CalculateGreeks(optionType model.OptionType, spot, strike, years, rate, volatility float64) model.Greeks {
+	return CalculateGreeksWithYield(optionType, spot, strike, years, rate, 0, volatility)
+}
+
+// CalculateGreeksWithYield calculates the per-share Black-Scholes greeks of a European option on an underlying paying a continuous yield
+func CalculateGreeksWithYield(optionType model.OptionType, spot, strike, years, rate, yield, volatility float64) model.Greeks {
+	// At expiry or without volatility the option behaves like its intrinsic value
+	if years <= 0 || volatility <= 0 {
+		var delta float64
+		forwardStrike := strike * math.Exp(-rate*math.Max(0, years))
+		forwardSpot := spot * math.Exp(-yield*math.Max(0, years))
+		if optionType == model.Call && forwardSpot > forwardStrike {
+			delta = 1
+		} else if optionType == model.Put && forwardSpot < forwardStrike {
+			delta = -1
+		}
+		return model.Greeks{Delta: delta}
+	}
+
+	d1, d2 := calculateD1D2(spot, strike, years, rate, yield, volatility)
+	sqrtT := math.Sqrt(years)
+	discount := math.Exp(-rate * years)
+	carry := math.Exp(-yield * years)
+
+	// Gamma and vega are the same for calls and puts
+	greeks := model.Greeks{
+		Gamma: carry * NormPDF(d1) / (spot * volatility * sqrtT),
+		Vega:  carry * spot * NormPDF(d1) * sqrtT / 100,
+	}
+	decay := -carry * spot * NormPDF(d1) * volatility / (2 * sqrtT)
+
+	if optionType == model.Put {
+		greeks.Delta = carry * (NormCDF(d1) - 1)
+		greeks.Theta = (decay + rate*strike*discount*NormCDF(-d2) - yield*spot*carry*NormCDF(-d1)) / TRADING_DAYS_PER_YEAR
+		greeks.Rho = -strike * years * discount * NormCDF(-d2) / 100
+		return greeks
+	}
+	greeks.Delta = carry * NormCDF(d1)
+	greeks.Theta = (decay - rate*strike*discount*NormCDF(d2) + yield*spot*carry*NormCDF(d1)) / TRADING_DAYS_PER_YEAR
+	greeks.Rho = strike * years * discount * NormCDF(d2) / 100
+	return greeks
+}
+
+// ContractGreeks calculates the per-share greeks of a contract at the given valuation time.
+// European options have closed-form greeks on the spot net of the dividends, american ones are bumped on the tree.
+func ContractGreeks(contract model.OptionsContract, spot float64, market Market, at time.Time) model.Greeks {
+	// A position in the underlying only has delta
+	if !contract.IsOption() {
+		return model.Greeks{Delta: 1}
+	}
+	years := YearsToExpiry(contract, at)
+	volatility := market.LegVolatility(contract, at)
+	if contract.ExerciseStyle == model.American {
+		// The volatility stays with the contract while it is bumped
+		return bumpGreeks(contract, spot, market.WithVolatility(volatility), at)
+	}
+	dividends := ScheduleDividends(market.Dividends, at, contract.ExpiresAt())
+	return CalculateGreeksWithYield(contract.Type, EscrowedSpot(spot, dividends, market.RiskFreeRate), contract.StrikePrice, years, market.RiskFreeRate, market.DividendYield, volatility)
+}
+
+// bumpGreeks calculates the greeks of a contract by finite differences of its value, in the same units as the closed-form greeks
+func bumpGreeks(contract model.OptionsContract, spot float64, market Market, at time.Time) model.Greeks {
+	value := ContractValue(contract, spot, market, at)
+	bump := spot * 0.01
+	up, down := ContractValue(contract, spot+bump, market, at), ContractValue(contract, spot-bump, market, at)
+
+	// Bump the volatility by a point either way, without letting it go below zero
+	volatilityUp, volatilityDown := market, market
+	volatilityUp.Volatility += 0.01
+	volatilityDown.Volatility = math.Max(0, market.Volatility-0.01)
+
+	// Bump the rate by a percentage point either way
+	rateUp, rateDown := market, market
+	rateUp.RiskFreeRate += 0.01
+	rateDown.RiskFreeRate -= 0.01
+
+	return model.Greeks{
+		Delta: (up - down) / (2 * bump),
+		Gamma: (up - 2*value + down) / (bump * bump),
+		Theta: ContractValue(contract, spot, market, DateAfter(at, 1/TRADING_DAYS_PER_YEAR)) - value,
+		Vega:  (ContractValue(contract, spot, volatilityUp, at) - ContractValue(contract, spot, volatilityDown, at)) / ((volatilityUp.Volatility - volatilityDown.Volatility) * 100),
+		Rho:   (ContractValue(contract, spot, rateUp, at) - ContractValue(contract, spot, rateDown, at)) / 2,
+	}
+}
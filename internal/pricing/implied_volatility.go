@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+const (
+	MIN_VOLATILITY = 1e-6 // Lower bound of the implied volatility search
+	MAX_VOLATILITY = 5.0  // Upper bound of the implied volatility search (500%)
+)
+
+// ImpliedVolatility solves for the volatility at which the Black-Scholes value equals the premium.
+// It uses Newton's method and falls back to bisection whenever a Newton step leaves the bracket, and fails when neither converges.
+func ImpliedVolatility(optionType model.OptionType, premium, spot, strike, years, rate float64) (float64, error) {
+	return ImpliedVolatilityWithYield(optionType, premium, spot, strike, years, rate, 0)
+}
+
+// ImpliedVolatilityWithYield solves for the volatility at which the Black-Scholes value of an option on an underlying paying a continuous yield equals the premium
+func ImpliedVolatilityWithYield(optionType model.OptionType, premium, spot, strike, years, rate, yield float64) (float64, error) {
+	const tolerance = 1e-8    // Define the tolerance on the price difference
+	const maxIterations = 100 // Define the maximum number of iterations
+
+	// An expired option has no time value to back a volatility out of
+	if years <= 0 {
+		return 0, errors.New("option has expired")
+	}
+
+	// The premium has to lie within the prices reachable by the search bracket
+	low, high := MIN_VOLATILITY, MAX_VOLATILITY
+	if premium < BlackScholesMerton(optionType, spot, strike, years, rate, yield, low) || premium > BlackScholesMerton(optionType, spot, strike, years, rate, yield, high) {
+		return 0, errors.New("premium is outside of the option's price bounds")
+	}
+
+	volatility := 0.3 // Start from a typical equity volatility
+	for i := 0; i < maxIterations; i++ {
+		difference := BlackScholesMerton(optionType, spot, strike, years, rate, yield, volatility) - premium
+		if math.Abs(difference) < tolerance {
+			return volatility, nil
+		}
+
+		// Tighten the bracket around the root, the price increases with the volatility
+		if difference > 0 {
+			high = volatility
+		} else {
+			low = volatility
+		}
+
+		// Take a Newton step and fall back to the bracket midpoint when it overshoots
+		d1, _ := calculateD1D2(spot, strike, years, rate, yield, volatility)
+		vega := spot * math.Exp(-yield*years) * NormPDF(d1) * math.Sqrt(years)
+		next := volatility - difference/vega
+		if vega == 0 || math.IsNaN(next) || next <= low || next >= high {
+			next = (low + high) / 2
+		}
+		volatility = next
+	}
+
+	// The last iterate is not a volatility the premium is priced at, so there is none to report
+	return 0, errors.New("implied volatility did not converge")
+}
+
+// ContractImpliedVolatility solves for the volatility at which the theoretical value of a contract equals the premium.
+// European options are solved on the spot net of the dividends, american ones by bisection on the tree.
+func ContractImpliedVolatility(contract model.OptionsContract, premium, spot float64, carry model.Carry, at time.Time) (float64, error) {
+	const tolerance = 1e-6 // Define the tolerance on the volatility
+	years := YearsToExpiry(contract, at)
+	dividends := ScheduleDividends(carry.Dividends, at, contract.ExpiresAt())
+	if contract.ExerciseStyle != model.American {
+		return ImpliedVolatilityWithYield(contract.Type, premium, EscrowedSpot(spot, dividends, carry.RiskFreeRate), contract.StrikePrice, years, carry.RiskFreeRate, carry.DividendYield)
+	}
+
+	// An expired option has no time value to back a volatility out of
+	if years <= 0 {
+		return 0, errors.New("option has expired")
+	}
+	value := func(volatility float64) float64 {
+		return BinomialTree(contract.Type, true, spot, contract.StrikePrice, years, carry.RiskFreeRate, carry.DividendYield, volatility, dividends)
+	}
+	low, high := MIN_VOLATILITY, MAX_VOLATILITY
+	if premium < value(low) || premium > value(high) {
+		return 0, errors.New("premium is outside of the option's price bounds")
+	}
+
+	// The value increases with the volatility so the bracket closes in on the premium
+	for high-low > tolerance {
+		middle := (low + high) / 2
+		if value(middle) > premium {
+			high = middle
+		} else {
+			low = middle
+		}
+	}
+	return (low + high) / 2, nil
+}
@@ -0,0 +1,49 @@
+package pricing
+
+import "math"
+
+// LognormalCDF calculates the risk-neutral probability that an underlying paying a continuous yield finishes at or below a price after a number of years
+func LognormalCDF(price, spot, years, rate, yield, volatility float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+	if math.IsInf(price, 1) {
+		return 1
+	}
+	// Without volatility or time the underlying finishes at its forward for sure
+	if volatility <= 0 || years <= 0 {
+		if price >= forwardPrice(spot, years, rate, yield) {
+			return 1
+		}
+		return 0
+	}
+	_, d2 := calculateD1D2(spot, price, years, rate, yield, volatility)
+	return NormCDF(-d2)
+}
+
+// LognormalPartialExpectation calculates the risk-neutral expectation of the underlying price over the outcomes where it finishes between two prices
+func LognormalPartialExpectation(low, high, spot, years, rate, yield, volatility float64) float64 {
+	// The share of the expected price above a price, the whole of it above zero
+	above := func(price float64) float64 {
+		if price <= 0 {
+			return 1
+		}
+		if math.IsInf(price, 1) {
+			return 0
+		}
+		if volatility <= 0 || years <= 0 {
+			if forwardPrice(spot, years, rate, yield) > price {
+				return 1
+			}
+			return 0
+		}
+		d1, _ := calculateD1D2(spot, price, years, rate, yield, volatility)
+		return NormCDF(d1)
+	}
+	return forwardPrice(spot, years, rate, yield) * (above(low) - above(high))
+}
+
+// forwardPrice calculates the expected price of the underlying after a number of years
+func forwardPrice(spot, years, rate, yield float64) float64 {
+	return spot * math.Exp((rate-yield)*math.Max(0, years))
+}
@@ -0,0 +1,58 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+// Market represents the inputs every model valuation is made with, besides the spot and the valuation time
+type Market struct {
+	Volatility float64  // Flat volatility used when there is no surface
+	Surface    *Surface // Volatility surface every option looks up its own volatility on
+	Shock      float64  // Shift added to every volatility, to value the contracts in a scenario
+	model.Carry
+}
+
+// HasVolatility returns whether the market can value contracts before their expiry
+func (m Market) HasVolatility() bool {
+	return m.Volatility > 0 || m.Surface != nil
+}
+
+// LegVolatility returns the volatility of a contract, looked up on the surface at its strike and expiration when there is one
+func (m Market) LegVolatility(contract model.OptionsContract, at time.Time) float64 {
+	if m.Surface == nil || !contract.IsOption() {
+		return m.shocked(m.Volatility)
+	}
+	return m.shocked(m.Surface.Volatility(contract.StrikePrice, contract.ExpirationDate, at))
+}
+
+// UnderlyingVolatility returns the volatility of the underlying itself until the horizon, at the money on the surface when there is one
+func (m Market) UnderlyingVolatility(spot float64, horizon, at time.Time) float64 {
+	if m.Surface == nil {
+		return m.shocked(m.Volatility)
+	}
+	return m.shocked(m.Surface.Volatility(spot, horizon, at))
+}
+
+// WithVolatility returns the market with a flat volatility in place of the surface and the shock
+func (m Market) WithVolatility(volatility float64) Market {
+	m.Volatility, m.Surface, m.Shock = volatility, nil, 0
+	return m
+}
+
+// WithShock returns the market with every volatility shifted by the shock
+func (m Market) WithShock(shock float64) Market {
+	m.Shock = shock
+	return m
+}
+
+// shocked shifts a volatility by the shock of the market, a volatility cant be shocked below zero
+func (m Market) shocked(volatility float64) float64 {
+	return max(volatility+m.Shock, 0)
+}
+
+// EscrowedSpot calculates the spot net of the present value of the dividends, the part of the underlying the options are written on
+func EscrowedSpot(spot float64, dividends []ScheduledDividend, rate float64) float64 {
+	return max(spot-DividendsPresentValue(dividends, 0, rate), 0)
+}
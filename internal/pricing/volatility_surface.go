@@ -0,0 +1,128 @@
+package pricing
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+)
+
+// Surface interpolates a volatility surface, linearly in volatility across the strikes of an expiration
+// and linearly in total variance across the expirations, with flat extrapolation on every side
+type Surface struct {
+	slices []surfaceSlice // Sorted by expiration
+}
+
+// surfaceSlice represents the volatilities of a single expiration
+type surfaceSlice struct {
+	expiration   time.Time
+	strikes      []float64 // Sorted quoted strikes of a grid slice
+	volatilities []float64 // Volatilities quoted at the strikes
+	svi          *model.SVISlice
+}
+
+// NewSurface builds the interpolator of a volatility surface
+func NewSurface(surface model.VolatilitySurface) *Surface {
+	byExpiration := map[time.Time]*surfaceSlice{}
+	var slices []*surfaceSlice
+	for _, point := range surface.Points {
+		expiration := point.ExpirationDate.UTC()
+		slice, ok := byExpiration[expiration]
+		if !ok {
+			slice = &surfaceSlice{expiration: expiration}
+			byExpiration[expiration] = slice
+			slices = append(slices, slice)
+		}
+		slice.strikes = append(slice.strikes, point.StrikePrice)
+		slice.volatilities = append(slice.volatilities, point.Volatility)
+	}
+	for i := range surface.SVI {
+		slices = append(slices, &surfaceSlice{expiration: surface.SVI[i].ExpirationDate.UTC(), svi: &surface.SVI[i]})
+	}
+
+	result := &Surface{}
+	for _, slice := range slices {
+		// Sort the strikes of the slice along with their volatilities
+		sort.Sort(byStrike{slice})
+		result.slices = append(result.slices, *slice)
+	}
+	sort.Slice(result.slices, func(i, j int) bool {
+		return result.slices[i].expiration.Before(result.slices[j].expiration)
+	})
+	return result
+}
+
+// Volatility looks up the volatility of a strike and expiration at the valuation time
+func (s *Surface) Volatility(strike float64, expiration, at time.Time) float64 {
+	years := YearsBetween(at, expiration)
+
+	// Only the expirations with trading time left carry any variance
+	var live []surfaceSlice
+	for _, slice := range s.slices {
+		if slice.years(at) > 0 {
+			live = append(live, slice)
+		}
+	}
+	if len(live) == 0 {
+		last := s.slices[len(s.slices)-1]
+		return last.volatility(strike, last.years(at))
+	}
+
+	first, last := live[0], live[len(live)-1]
+	if years <= first.years(at) {
+		return first.volatility(strike, first.years(at))
+	}
+	if years >= last.years(at) {
+		return last.volatility(strike, last.years(at))
+	}
+
+	// Interpolate the total variance between the expirations on either side
+	index := sort.Search(len(live), func(i int) bool { return live[i].years(at) >= years })
+	before, after := live[index-1], live[index]
+	beforeYears, afterYears := before.years(at), after.years(at)
+	beforeVariance := math.Pow(before.volatility(strike, beforeYears), 2) * beforeYears
+	afterVariance := math.Pow(after.volatility(strike, afterYears), 2) * afterYears
+	variance := beforeVariance + (afterVariance-beforeVariance)*(years-beforeYears)/(afterYears-beforeYears)
+	return math.Sqrt(math.Max(variance, 0) / years)
+}
+
+// years calculates the time in years from the valuation time until the slice expires
+func (s surfaceSlice) years(at time.Time) float64 {
+	return YearsBetween(at, s.expiration)
+}
+
+// volatility calculates the volatility of a strike on the slice expiring a number of years away
+func (s surfaceSlice) volatility(strike, years float64) float64 {
+	if s.svi != nil {
+		if years <= 0 {
+			return 0
+		}
+		k := math.Log(strike/s.svi.Forward) - s.svi.M
+		variance := s.svi.A + s.svi.B*(s.svi.Rho*k+math.Sqrt(k*k+s.svi.Sigma*s.svi.Sigma))
+		return math.Sqrt(math.Max(variance, 0) / years)
+	}
+
+	// Flat beyond the quoted strikes and linear in between
+	index := sort.SearchFloat64s(s.strikes, strike)
+	if index == 0 {
+		return s.volatilities[0]
+	}
+	if index == len(s.strikes) {
+		return s.volatilities[len(s.volatilities)-1]
+	}
+	low, high := s.strikes[index-1], s.strikes[index]
+	return s.volatilities[index-1] + (s.volatilities[index]-s.volatilities[index-1])*(strike-low)/(high-low)
+}
+
+// byStrike sorts the quoted strikes of a slice along with their volatilities
+type byStrike struct {
+	slice *surfaceSlice
+}
+
+func (b byStrike) Len() int           { return len(b.slice.strikes) }
+func (b byStrike) Less(i, j int) bool { return b.slice.strikes[i] < b.slice.strikes[j] }
+func (b byStrike) Swap(i, j int) {
+	b.slice.strikes[i], b.slice.strikes[j] = b.slice.strikes[j], b.slice.strikes[i]
+	b.slice.volatilities[i], b.slice.volatilities[j] = b.slice.volatilities[j], b.slice.volatilities[i]
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
@@ -11,40 +13,104 @@ import (
 func (s *Server) RegisterRoutes() http.Handler {
 	r := gin.Default()
 	r.POST("/analyze", s.AnaylzeHandler)
+	r.POST("/v2/analyze", s.AnalyzeV2Handler)
+	r.POST("/scenario", s.ScenarioHandler)
 
 	return r
 }
 
 func (s *Server) AnaylzeHandler(c *gin.Context) {
-	var contracts []model.OptionsContract
+	request, ok := s.bindAnalysisRequest(c)
+	if !ok {
+		return
+	}
 
-	// Extract the incoming json POST request data
-	if err := c.ShouldBindJSON(&contracts); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// Analyze Contracts. A contract without a quantity or multiplier is a single contract of 100 shares.
+	analysis := analysis.AnalyzeRequest(request, s.clock())
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+func (s *Server) AnalyzeV2Handler(c *gin.Context) {
+	request, ok := s.bindAnalysisRequest(c)
+	if !ok {
 		return
 	}
 
-	// Make sure that we cant have more than 4 contracts
-	if len(contracts) > 4 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "only accepting at most 4 options contracts"})
+	// Analyze Contracts with the maximum profit and maximum loss as numbers
+	analysis := analysis.AnalyzeRequestV2(request, s.clock())
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+func (s *Server) ScenarioHandler(c *gin.Context) {
+	var request model.ScenarioRequest
+
+	// Extract the incoming json POST request data
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, decodingErrors(err))
 		return
 	}
 
-	// Make sure that we cant have 0 contracts
-	if len(contracts) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "need at least one options contracts"})
+	// The contracts given as OCC symbols are filled in from them
+	model.ResolveSymbols(request.Contracts)
+
+	// Pin the valuation time so the contracts are checked and valued as of the same time
+	valuationTime := request.AnalysisRequest().ValuationTime(s.clock())
+	request.ValuationDate = &valuationTime
+
+	// Collect every error of the contracts and of the scenario inputs
+	errs := model.ValidateContracts(request.Contracts, s.maxLegs(), valuationTime)
+	errs = append(errs, model.ValidateScenarioRequest(request)...)
+	if len(errs) > 0 {
+		respondProblem(c, errs)
 		return
 	}
 
-	for _, contract := range contracts {
-		if err := model.IsOptionsContractValid(contract); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
+	// Build the profit/loss grid across the requested scenarios
+	grid := analysis.AnalyzeScenarios(request, s.clock())
+
+	c.JSON(http.StatusOK, grid)
+}
+
+// bindAnalysisRequest extracts and validates the analysis request, it responds with the problem and reports false when the request is invalid
+func (s *Server) bindAnalysisRequest(c *gin.Context) (model.AnalysisRequest, bool) {
+	var request model.AnalysisRequest
+
+	// Extract the incoming json POST request data
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, decodingErrors(err))
+		return request, false
 	}
 
-	// Analyze Contracts. I am also assuming that the contracts are holding 100 share since the option size isnt mentioned.
-	analysis := analysis.AnalyzeContracts(contracts)
+	// The contracts given as OCC symbols are filled in from them
+	model.ResolveSymbols(request.Contracts)
 
-	c.JSON(http.StatusOK, analysis)
+	// Pin the valuation time so the contracts are checked and valued as of the same time
+	valuationTime := request.ValuationTime(s.clock())
+	request.ValuationDate = &valuationTime
+
+	// Collect every error of the contracts and of the model inputs
+	errs := model.ValidateContracts(request.Contracts, s.maxLegs(), valuationTime)
+	errs = append(errs, model.ValidateAnalysisRequest(request)...)
+	if len(errs) > 0 {
+		respondProblem(c, errs)
+		return request, false
+	}
+	return request, true
+}
+
+// respondProblem responds with the validation errors of the request as an RFC 7807 problem
+func respondProblem(c *gin.Context, errs []model.ValidationError) {
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(http.StatusBadRequest, model.NewValidationProblem(http.StatusBadRequest, errs))
+}
+
+// decodingErrors describes why the body could not be decoded into the request, locating the field of a mistyped value
+func decodingErrors(err error) []model.ValidationError {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		return []model.ValidationError{{Field: typeError.Field, Code: model.CodeInvalid, Message: err.Error()}}
+	}
+	return []model.ValidationError{{Code: model.CodeMalformed, Message: err.Error()}}
 }
@@ -7,17 +7,24 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
 	_ "github.com/joho/godotenv/autoload"
 )
 
+const DEFAULT_MAX_LEGS = 4 // Most contracts a request can hold when the server does not configure it
+
 type Server struct {
-	port int
+	port    int
+	MaxLegs int         // Most contracts a request can hold, DEFAULT_MAX_LEGS when zero
+	Clock   model.Clock // Time the requests without a valuation date are checked and valued at, the system clock when nil
 }
 
 func NewServer() *http.Server {
 	port, _ := strconv.Atoi(os.Getenv("PORT"))
+	maxLegs, _ := strconv.Atoi(os.Getenv("MAX_LEGS"))
 	NewServer := &Server{
-		port: port,
+		port:    port,
+		MaxLegs: maxLegs,
 	}
 
 	// Declare Server config
@@ -31,3 +38,19 @@ func NewServer() *http.Server {
 
 	return server
 }
+
+// maxLegs returns the most contracts a request can hold
+func (s *Server) maxLegs() int {
+	if s.MaxLegs <= 0 {
+		return DEFAULT_MAX_LEGS
+	}
+	return s.MaxLegs
+}
+
+// clock returns the clock the requests are checked and valued with
+func (s *Server) clock() model.Clock {
+	if s.Clock == nil {
+		return model.SystemClock{}
+	}
+	return s.Clock
+}
@@ -19,6 +19,9 @@ func readFileContent(filename string) ([]byte, error) {
 	return os.ReadFile(filename)
 }
 
+// The testdata expires on 2025-12-17, so it is checked and valued as of a date before it
+var testdataClock = model.FixedClock(time.Date(2025, 6, 17, 0, 0, 0, 0, time.UTC))
+
 var _ = Describe("Analyze Endpoint", func() {
 	var router http.Handler
 
@@ -57,6 +60,161 @@ var _ = Describe("Analyze Endpoint", func() {
 			Expect(analysis.RiskRewardGraph).NotTo(BeEmpty())
 			Expect(analysis.MaxProfit).To(Equal("+Inf"))
 			Expect(analysis.MaxLoss).To(Equal("-1200.00"))
+			Expect(analysis.Margin).NotTo(BeNil())
+			Expect(analysis.Margin.BuyingPowerEffect).To(Equal(1200.0))
+			Expect(analysis.Margin.ReturnOnCapital).To(BeNil())
+		})
+
+		It("should return theoretical curves when a volatility is given", func() {
+			beforeEach()
+
+			request := model.AnalysisRequest{
+				Contracts: []model.OptionsContract{
+					{
+						Type:           model.Call,
+						LongShort:      model.Long,
+						StrikePrice:    100.0,
+						Bid:            10.0,
+						Ask:            12.0,
+						ExpirationDate: time.Now().AddDate(0, 1, 0),
+					},
+				},
+				Volatility:   0.25,
+				RiskFreeRate: 0.05,
+				CurveDays:    []int{0, 7},
+			}
+
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var analysis model.Analysis
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.TheoreticalCurves).To(HaveLen(2))
+			Expect(analysis.TheoreticalCurves[1].DaysForward).To(Equal(7))
+			Expect(analysis.TheoreticalCurves[0].RiskRewardGraph).To(HaveLen(len(analysis.RiskRewardGraph)))
+		})
+
+		It("should return the position greeks and probabilities when an underlying price is given", func() {
+			beforeEach()
+
+			request := model.AnalysisRequest{
+				Contracts: []model.OptionsContract{
+					{
+						Type:           model.Put,
+						LongShort:      model.Long,
+						StrikePrice:    100.0,
+						Bid:            4.0,
+						Ask:            5.0,
+						ExpirationDate: time.Now().AddDate(0, 1, 0),
+					},
+				},
+				UnderlyingPrice: 100,
+				Volatility:      0.25,
+			}
+
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var analysis model.Analysis
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.Greeks).NotTo(BeNil())
+			Expect(analysis.Greeks.Legs).To(HaveLen(1))
+			Expect(analysis.Greeks.Total.Delta).To(BeNumerically("<", 0))
+			Expect(analysis.Greeks.Total.Theta).To(BeNumerically("<", 0))
+			Expect(analysis.Probabilities).NotTo(BeNil())
+			Expect(analysis.Probabilities.ProbabilityOfProfit).To(BeNumerically("<", 0.5))
+			Expect(analysis.Probabilities.ProbabilityOfMaxLoss).To(BeNumerically(">", 0.4))
+		})
+
+		It("should value the contracts in the analysis context", func() {
+			beforeEach()
+
+			valuationDate := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+			request := model.AnalysisRequest{
+				Contracts: []model.OptionsContract{
+					{
+						Type:           model.Call,
+						LongShort:      model.Long,
+						StrikePrice:    100.0,
+						Bid:            4.0,
+						Ask:            5.0,
+						ExpirationDate: time.Now().AddDate(0, 1, 0),
+					},
+				},
+				Volatility: 0.25,
+				Context: &model.AnalysisContext{
+					UnderlyingPrice: 100,
+					Carry:           model.Carry{RiskFreeRate: 0.05, DividendYield: 0.02},
+					ValuationDate:   &valuationDate,
+				},
+			}
+
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var analysis model.Analysis
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.TheoreticalCurves).To(HaveLen(1))
+			Expect(analysis.TheoreticalCurves[0].ValuationDate).To(BeTemporally("==", valuationDate))
+			Expect(analysis.Greeks).NotTo(BeNil())
+			Expect(analysis.Probabilities).NotTo(BeNil())
+			Expect(analysis.ImpliedVolatilities).To(HaveLen(1))
+		})
+
+		It("should return the results net of the fees", func() {
+			beforeEach()
+
+			request := model.AnalysisRequest{
+				Contracts: []model.OptionsContract{
+					{
+						Type:           model.Call,
+						LongShort:      model.Long,
+						StrikePrice:    100.0,
+						Bid:            10.0,
+						Ask:            12.0,
+						ExpirationDate: time.Now().AddDate(0, 1, 0),
+					},
+				},
+				Fees: &model.FeeSchedule{PerContract: 0.65, PerOrder: 1},
+			}
+
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var analysis model.Analysis
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.MaxLoss).To(Equal("-1201.65"))
+			Expect(analysis.Fees).NotTo(BeNil())
+			Expect(analysis.Fees.GrossMaxLoss).To(Equal("-1200.00"))
+			Expect(analysis.Fees.NetMaxLoss).To(Equal("-1201.65"))
 		})
 
 		It("should return error for more than 4 contracts", func() {
@@ -116,6 +274,44 @@ var _ = Describe("Analyze Endpoint", func() {
 			Expect(w.Body.String()).To(ContainSubstring("only accepting at most 4 options contracts"))
 		})
 
+		It("should accept more contracts when the server is configured for them", func() {
+			server := &server.Server{MaxLegs: 12}
+			router = server.RegisterRoutes()
+
+			expiration := time.Now().AddDate(0, 1, 0)
+			var contracts []model.OptionsContract
+			for i := 0; i < 12; i++ {
+				contracts = append(contracts, model.OptionsContract{
+					Type:           model.Call,
+					LongShort:      model.Long,
+					StrikePrice:    80.0 + 5*float64(i),
+					Bid:            2.0,
+					Ask:            2.5,
+					ExpirationDate: expiration,
+				})
+			}
+
+			body, _ := json.Marshal(contracts)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			// One more contract than configured is rejected with the configured cap
+			body, _ = json.Marshal(append(contracts, contracts[0]))
+			req, _ = http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("only accepting at most 12 options contracts"))
+		})
+
 		It("should return error for no contracts", func() {
 			beforeEach()
 
@@ -195,6 +391,110 @@ var _ = Describe("Analyze Endpoint", func() {
 			Expect(analysis.BreakEvenPoints).NotTo(Equal(106))
 		})
 
+		It("should return every validation error as a problem", func() {
+			beforeEach()
+			expiration := time.Now().AddDate(0, 1, 0)
+
+			contracts := []model.OptionsContract{
+				{
+					Type:           model.Call,
+					LongShort:      model.Long,
+					StrikePrice:    100.0,
+					Bid:            -10.0,
+					Ask:            12.0,
+					ExpirationDate: expiration,
+				},
+				{
+					Type:           model.Put,
+					LongShort:      model.Short,
+					StrikePrice:    -90.0,
+					Bid:            5.0,
+					Ask:            -6.0,
+					ExpirationDate: expiration,
+				},
+			}
+
+			body, _ := json.Marshal(contracts)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+
+			var problem model.Problem
+			err := json.Unmarshal(w.Body.Bytes(), &problem)
+			Expect(err).To(BeNil())
+			Expect(problem.Status).To(Equal(http.StatusBadRequest))
+			Expect(problem.Errors).To(HaveLen(3))
+			Expect(*problem.Errors[0].Leg).To(Equal(0))
+			Expect(problem.Errors[0].Field).To(Equal("contracts[0].bid"))
+			Expect(*problem.Errors[1].Leg).To(Equal(1))
+			Expect(problem.Errors[1].Field).To(Equal("contracts[1].strike_price"))
+			Expect(problem.Errors[1].Code).To(Equal(model.CodeNotPositive))
+			Expect(problem.Errors[2].Field).To(Equal("contracts[1].ask"))
+			Expect(problem.Detail).To(ContainSubstring("bid must be non-negative"))
+		})
+
+		It("should return the market-sanity warnings and fail a crossed quote", func() {
+			beforeEach()
+			expiration := time.Now().AddDate(0, 1, 0)
+
+			request := model.AnalysisRequest{
+				Contracts: []model.OptionsContract{
+					{Type: model.Call, LongShort: model.Long, StrikePrice: 100.0, Bid: 10.0, Ask: 12.0, ExpirationDate: expiration},
+					{Type: model.Call, LongShort: model.Long, StrikePrice: 100.0, Bid: 10.0, Ask: 12.0, ExpirationDate: expiration},
+				},
+			}
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var analysis model.Analysis
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.Warnings).To(HaveLen(1))
+			Expect(analysis.Warnings[0].Rule).To(Equal(model.RuleDuplicateLegs))
+			Expect(*analysis.Warnings[0].Leg).To(Equal(1))
+
+			// A crossed quote fails the request unless its rule is downgraded
+			request.Contracts[1].Bid = 13.0
+			body, _ = json.Marshal(request)
+			req, _ = http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			var problem model.Problem
+			err = json.Unmarshal(w.Body.Bytes(), &problem)
+			Expect(err).To(BeNil())
+			Expect(problem.Errors).To(HaveLen(1))
+			Expect(problem.Errors[0].Rule).To(Equal(model.RuleBidAsk))
+			Expect(problem.Errors[0].Field).To(Equal("contracts[1].bid"))
+
+			request.Sanity = &model.SanitySettings{Rules: map[model.SanityRuleName]model.RuleSeverity{model.RuleBidAsk: model.SeverityWarning}}
+			body, _ = json.Marshal(request)
+			req, _ = http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			err = json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.Warnings).To(HaveLen(2))
+			Expect(analysis.Warnings[0].Rule).To(Equal(model.RuleBidAsk))
+		})
+
 		It("should return error for invalid option type", func() {
 			beforeEach()
 
@@ -345,10 +645,101 @@ var _ = Describe("Analyze Endpoint", func() {
 			Expect(w.Body.String()).To(ContainSubstring("expiration date must be in the future"))
 		})
 
-		It("should return analysis with 2 break even points", func() {
+		It("should analyze expired contracts as of the valuation date", func() {
 			beforeEach()
 
-			inputData, err := readFileContent("../../testdata/multiple_break_even_95.51_114.5.json")
+			valuationDate := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+			request := model.AnalysisRequest{
+				Contracts: []model.OptionsContract{
+					{Type: model.Call, LongShort: model.Long, StrikePrice: 100.0, Bid: 4.0, Ask: 4.2, ExpirationDate: time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC)},
+				},
+				ValuationDate: &valuationDate,
+			}
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var analysis model.Analysis
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.MaxLoss).To(Equal("-420.00"))
+			// The bare expiration date settles at the close in New York
+			Expect(analysis.Expirations).To(HaveLen(1))
+			Expect(analysis.Expirations[0].ExpiresAt).To(BeTemporally("==", time.Date(2020, 3, 20, 20, 0, 0, 0, time.UTC)))
+			Expect(analysis.Expirations[0].TradingDays).To(Equal(55))
+
+			// Without a valuation date the contract is checked as of the server clock
+			request.ValuationDate = nil
+			body, _ = json.Marshal(request)
+			req, _ = http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("expiration date must be in the future"))
+
+			server := &server.Server{Clock: model.FixedClock(valuationDate)}
+			router = server.RegisterRoutes()
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should accept contracts given as OCC symbols and emit the symbol of every leg", func() {
+			server := &server.Server{Clock: testdataClock}
+			router = server.RegisterRoutes()
+
+			body := []byte(`[
+				{"symbol": "AAPL  251217C00100000", "long_short": "long", "bid": 10.05, "ask": 12.04},
+				{"type": "Call", "strike_price": 110, "expiration_date": "2025-12-17T00:00:00Z", "root": "AAPL", "long_short": "short", "bid": 5.1, "ask": 5.6}
+			]`)
+			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var analysis model.Analysis
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.Strategy.Name).To(Equal("Bull Call Spread"))
+			Expect(analysis.Expirations).To(HaveLen(2))
+			Expect(analysis.Expirations[0].Symbol).To(Equal("AAPL  251217C00100000"))
+			Expect(analysis.Expirations[1].Symbol).To(Equal("AAPL  251217C00110000"))
+
+			// A symbol the contract contradicts fails the request
+			body = []byte(`[{"symbol": "AAPL  251217C00100000", "type": "Put", "long_short": "long", "bid": 10.05, "ask": 12.04}]`)
+			req, _ = http.NewRequest("POST", "/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			var problem model.Problem
+			err = json.Unmarshal(w.Body.Bytes(), &problem)
+			Expect(err).To(BeNil())
+			Expect(problem.Errors).To(HaveLen(1))
+			Expect(problem.Errors[0].Field).To(Equal("contracts[0].type"))
+			Expect(problem.Errors[0].Code).To(Equal(model.CodeConflict))
+		})
+
+		It("should return analysis with 2 break even points", func() {
+			server := &server.Server{Clock: testdataClock}
+			router = server.RegisterRoutes()
+
+			inputData, err := readFileContent("../../testdata/multiple_break_even_95.25_114.75.json")
 			Expect(err).To(BeNil())
 
 			req, _ := http.NewRequest("POST", "/analyze", bytes.NewBuffer(inputData))
@@ -365,48 +756,57 @@ var _ = Describe("Analyze Endpoint", func() {
 			Expect(analysis.RiskRewardGraph).NotTo(BeEmpty())
 
 			expectedGraph := []model.RiskRewardGraph{
-				{UnderlyingPrice: 75, ProfitLoss: -250},
-				{UnderlyingPrice: 77, ProfitLoss: -250},
-				{UnderlyingPrice: 79, ProfitLoss: -250},
-				{UnderlyingPrice: 81, ProfitLoss: -250},
-				{UnderlyingPrice: 83, ProfitLoss: -250},
-				{UnderlyingPrice: 85, ProfitLoss: -250},
-				{UnderlyingPrice: 87, ProfitLoss: -250},
-				{UnderlyingPrice: 89, ProfitLoss: -250},
-				{UnderlyingPrice: 91, ProfitLoss: -250},
-				{UnderlyingPrice: 93, ProfitLoss: -250},
-				{UnderlyingPrice: 95, ProfitLoss: -250},
-				{UnderlyingPrice: 97, ProfitLoss: -450},
-				{UnderlyingPrice: 99, ProfitLoss: -650},
-				{UnderlyingPrice: 101, ProfitLoss: -750},
-				{UnderlyingPrice: 103, ProfitLoss: -750},
-				{UnderlyingPrice: 105, ProfitLoss: -750},
-				{UnderlyingPrice: 107, ProfitLoss: -750},
-				{UnderlyingPrice: 109, ProfitLoss: -750},
-				{UnderlyingPrice: 111, ProfitLoss: -650},
-				{UnderlyingPrice: 113, ProfitLoss: -450},
-				{UnderlyingPrice: 115, ProfitLoss: -250},
-				{UnderlyingPrice: 117, ProfitLoss: -250},
-				{UnderlyingPrice: 119, ProfitLoss: -250},
-				{UnderlyingPrice: 121, ProfitLoss: -250},
-				{UnderlyingPrice: 123, ProfitLoss: -250},
-				{UnderlyingPrice: 125, ProfitLoss: -250},
-				{UnderlyingPrice: 127, ProfitLoss: -250},
-				{UnderlyingPrice: 129, ProfitLoss: -250},
-				{UnderlyingPrice: 131, ProfitLoss: -250},
-				{UnderlyingPrice: 133, ProfitLoss: -250},
-				{UnderlyingPrice: 135, ProfitLoss: -250},
+				{UnderlyingPrice: 75, ProfitLoss: 25},
+				{UnderlyingPrice: 77, ProfitLoss: 25},
+				{UnderlyingPrice: 79, ProfitLoss: 25},
+				{UnderlyingPrice: 81, ProfitLoss: 25},
+				{UnderlyingPrice: 83, ProfitLoss: 25},
+				{UnderlyingPrice: 85, ProfitLoss: 25},
+				{UnderlyingPrice: 87, ProfitLoss: 25},
+				{UnderlyingPrice: 89, ProfitLoss: 25},
+				{UnderlyingPrice: 91, ProfitLoss: 25},
+				{UnderlyingPrice: 93, ProfitLoss: 25},
+				{UnderlyingPrice: 95, ProfitLoss: 25},
+				{UnderlyingPrice: 97, ProfitLoss: -175},
+				{UnderlyingPrice: 99, ProfitLoss: -375},
+				{UnderlyingPrice: 100, ProfitLoss: -475},
+				{UnderlyingPrice: 101, ProfitLoss: -475},
+				{UnderlyingPrice: 103, ProfitLoss: -475},
+				{UnderlyingPrice: 105, ProfitLoss: -475},
+				{UnderlyingPrice: 107, ProfitLoss: -475},
+				{UnderlyingPrice: 109, ProfitLoss: -475},
+				{UnderlyingPrice: 110, ProfitLoss: -475},
+				{UnderlyingPrice: 111, ProfitLoss: -375},
+				{UnderlyingPrice: 113, ProfitLoss: -175},
+				{UnderlyingPrice: 115, ProfitLoss: 25},
+				{UnderlyingPrice: 117, ProfitLoss: 25},
+				{UnderlyingPrice: 119, ProfitLoss: 25},
+				{UnderlyingPrice: 121, ProfitLoss: 25},
+				{UnderlyingPrice: 123, ProfitLoss: 25},
+				{UnderlyingPrice: 125, ProfitLoss: 25},
+				{UnderlyingPrice: 127, ProfitLoss: 25},
+				{UnderlyingPrice: 129, ProfitLoss: 25},
+				{UnderlyingPrice: 131, ProfitLoss: 25},
+				{UnderlyingPrice: 133, ProfitLoss: 25},
+				{UnderlyingPrice: 135, ProfitLoss: 25},
 			}
 			Expect(analysis.RiskRewardGraph).To(Equal(expectedGraph))
-			Expect(analysis.MaxProfit).To(Equal("50.00"))
-			Expect(analysis.MaxLoss).To(Equal("-450.00"))
-
-			expectedBreakEvenPoints := []float64{95.51, 114.5}
+			Expect(analysis.MaxProfit).To(Equal("25.00"))
+			Expect(analysis.MaxLoss).To(Equal("-475.00"))
+			Expect(analysis.MaxProfitPrices).To(Equal([]float64{95, 115}))
+			Expect(analysis.MaxLossPrices).To(Equal([]float64{100, 110}))
+			Expect(analysis.FillMode).To(Equal(model.Mid))
+			Expect(analysis.NetPremium).To(Equal(475.0))
+			Expect(analysis.PremiumType).To(Equal(model.Debit))
+
+			// The fixture fills every leg at the mid, for a debit of 4.75 on spreads 5 wide
+			expectedBreakEvenPoints := []float64{95.25, 114.75}
 			Expect(analysis.BreakEvenPoints).To(Equal(expectedBreakEvenPoints))
 		})
 
 		It("should return analysis for 2 leg options", func() {
-			beforeEach()
+			server := &server.Server{Clock: testdataClock}
+			router = server.RegisterRoutes()
 
 			inputData, err := readFileContent("../../testdata/2leg.json")
 			Expect(err).To(BeNil())
@@ -440,7 +840,9 @@ var _ = Describe("Analyze Endpoint", func() {
 				{UnderlyingPrice: 97.00000000000006, ProfitLoss: -2604},
 				{UnderlyingPrice: 98.41666666666673, ProfitLoss: -2604},
 				{UnderlyingPrice: 99.8333333333334, ProfitLoss: -2604},
+				{UnderlyingPrice: 100, ProfitLoss: -2604},
 				{UnderlyingPrice: 101.25000000000007, ProfitLoss: -2478.99},
+				{UnderlyingPrice: 102.5, ProfitLoss: -2354},
 				{UnderlyingPrice: 102.66666666666674, ProfitLoss: -2320.66},
 				{UnderlyingPrice: 104.08333333333341, ProfitLoss: -2037.33},
 				{UnderlyingPrice: 105.50000000000009, ProfitLoss: -1753.99},
@@ -459,6 +861,8 @@ var _ = Describe("Analyze Endpoint", func() {
 			Expect(analysis.RiskRewardGraph).To(Equal(expectedGraph))
 			Expect(analysis.MaxProfit).To(Equal("+Inf"))
 			Expect(analysis.MaxLoss).To(Equal("-2604.00"))
+			Expect(analysis.MaxProfitPrices).To(BeEmpty())
+			Expect(analysis.MaxLossPrices).To(Equal([]float64{100}))
 
 			expectedBreakEvenPoints := []float64{114.27}
 			Expect(analysis.BreakEvenPoints).To(Equal(expectedBreakEvenPoints))
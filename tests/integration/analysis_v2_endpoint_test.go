@@ -0,0 +1,138 @@
+package unit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/server"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Analyze V2 Endpoint", func() {
+	var router http.Handler
+
+	beforeEach := func() {
+		server := &server.Server{}
+		router = server.RegisterRoutes()
+	}
+
+	Context("POST /v2/analyze", func() {
+		It("should flag an unbounded maximum profit", func() {
+			beforeEach()
+
+			contracts := []model.OptionsContract{
+				{
+					Type:           model.Call,
+					LongShort:      model.Long,
+					StrikePrice:    100.0,
+					Bid:            10.0,
+					Ask:            12.0,
+					ExpirationDate: time.Now().AddDate(0, 1, 0),
+				},
+			}
+
+			body, _ := json.Marshal(contracts)
+			req, _ := http.NewRequest("POST", "/v2/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring(`"max_profit":null`))
+			Expect(w.Body.String()).NotTo(ContainSubstring("Inf"))
+
+			var analysis model.AnalysisV2
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(analysis.MaxProfit).To(BeNil())
+			Expect(analysis.Metrics.UnboundedProfit).To(BeTrue())
+			Expect(*analysis.MaxLoss).To(Equal(-1200.0))
+			Expect(analysis.Metrics.UnboundedLoss).To(BeFalse())
+			Expect(analysis.MaxLossPrices).To(Equal([]float64{100}))
+			Expect(analysis.RiskRewardGraph).NotTo(BeEmpty())
+		})
+
+		It("should return a bounded maximum profit and loss as numbers", func() {
+			beforeEach()
+
+			expiration := time.Now().AddDate(0, 1, 0)
+			contracts := []model.OptionsContract{
+				{
+					Type:           model.Put,
+					LongShort:      model.Short,
+					StrikePrice:    95.0,
+					Bid:            2.0,
+					Ask:            2.2,
+					ExpirationDate: expiration,
+				},
+				{
+					Type:           model.Put,
+					LongShort:      model.Long,
+					StrikePrice:    90.0,
+					Bid:            0.9,
+					Ask:            1.0,
+					ExpirationDate: expiration,
+				},
+			}
+
+			body, _ := json.Marshal(contracts)
+			req, _ := http.NewRequest("POST", "/v2/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var analysis model.AnalysisV2
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(*analysis.MaxProfit).To(Equal(100.0))
+			Expect(*analysis.MaxLoss).To(Equal(-400.0))
+			Expect(analysis.Metrics.UnboundedProfit).To(BeFalse())
+			Expect(analysis.Metrics.UnboundedLoss).To(BeFalse())
+			Expect(analysis.MaxProfitPrices).To(Equal([]float64{95}))
+			Expect(analysis.MaxLossPrices).To(Equal([]float64{90}))
+		})
+
+		It("should bound the maximum loss of a short put at a price of zero", func() {
+			beforeEach()
+
+			contracts := []model.OptionsContract{
+				{
+					Type:           model.Put,
+					LongShort:      model.Short,
+					StrikePrice:    100.0,
+					Bid:            4.0,
+					Ask:            5.0,
+					ExpirationDate: time.Now().AddDate(0, 1, 0),
+				},
+			}
+
+			body, _ := json.Marshal(contracts)
+			req, _ := http.NewRequest("POST", "/v2/analyze", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).NotTo(ContainSubstring(`"max_loss":null`))
+
+			var analysis model.AnalysisV2
+			err := json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).To(BeNil())
+			Expect(*analysis.MaxProfit).To(Equal(400.0))
+			Expect(*analysis.MaxLoss).To(Equal(-9600.0))
+			Expect(analysis.Metrics.UnboundedLoss).To(BeFalse())
+			Expect(analysis.MaxLossPrices).To(Equal([]float64{0}))
+			Expect(analysis.BreakEvenPoints).To(Equal([]float64{96}))
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package unit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/server"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scenario Endpoint", func() {
+	var router http.Handler
+
+	beforeEach := func() {
+		server := &server.Server{}
+		router = server.RegisterRoutes()
+	}
+
+	Context("POST /scenario", func() {
+		It("should return the profit/loss grid across the axes", func() {
+			beforeEach()
+
+			request := model.ScenarioRequest{
+				Contracts: []model.OptionsContract{
+					{
+						Type:           model.Call,
+						LongShort:      model.Long,
+						StrikePrice:    100.0,
+						Bid:            10.0,
+						Ask:            12.0,
+						ExpirationDate: time.Now().AddDate(0, 1, 0),
+					},
+				},
+				UnderlyingPrice: 100,
+				Volatility:      0.3,
+				Axes: model.ScenarioAxes{
+					StandardDeviations: []float64{-1, 0, 1},
+					DaysForward:        []int{0, 7},
+					VolatilityShocks:   []float64{-0.05, 0.05},
+				},
+			}
+
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/scenario", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var grid model.ScenarioGrid
+			err := json.Unmarshal(w.Body.Bytes(), &grid)
+			Expect(err).To(BeNil())
+			Expect(grid.UnderlyingPrices).To(HaveLen(3))
+			Expect(grid.Dates).To(HaveLen(3))
+			Expect(grid.Dates[2].Expiry).To(BeTrue())
+			Expect(grid.ProfitLoss).To(HaveLen(3))
+			Expect(grid.ProfitLoss[0]).To(HaveLen(2))
+			Expect(grid.ProfitLoss[0][0]).To(HaveLen(3))
+			// At expiry the call bought at the ask loses it all below the strike
+			Expect(grid.ProfitLoss[2][0][0]).To(Equal(-1200.0))
+		})
+
+		It("should return error without a volatility", func() {
+			beforeEach()
+
+			request := model.ScenarioRequest{
+				Contracts: []model.OptionsContract{
+					{
+						Type:           model.Call,
+						LongShort:      model.Long,
+						StrikePrice:    100.0,
+						Bid:            10.0,
+						Ask:            12.0,
+						ExpirationDate: time.Now().AddDate(0, 1, 0),
+					},
+				},
+				UnderlyingPrice: 100,
+			}
+
+			body, _ := json.Marshal(request)
+			req, _ := http.NewRequest("POST", "/scenario", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("volatility is required to build scenarios"))
+		})
+
+		It("should reject a bare array of contracts", func() {
+			beforeEach()
+
+			contracts := []model.OptionsContract{
+				{
+					Type:           model.Call,
+					LongShort:      model.Long,
+					StrikePrice:    100.0,
+					Bid:            10.0,
+					Ask:            12.0,
+					ExpirationDate: time.Now().AddDate(0, 1, 0),
+				},
+			}
+
+			body, _ := json.Marshal(contracts)
+			req, _ := http.NewRequest("POST", "/scenario", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+			var problem model.Problem
+			err := json.Unmarshal(w.Body.Bytes(), &problem)
+			Expect(err).To(BeNil())
+			Expect(problem.Errors).To(HaveLen(1))
+			Expect(problem.Errors[0].Code).To(Equal(model.CodeInvalid))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Analysis context", func() {
+	now := time.Now()
+	expiration := now.AddDate(1, 0, 0)
+	call := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 10, Ask: 10, ExpirationDate: expiration}
+	put := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 5, ExpirationDate: expiration}
+
+	It("should fall back on the top-level underlying price and risk-free rate", func() {
+		request := model.AnalysisRequest{UnderlyingPrice: 100, RiskFreeRate: 0.05}
+		Expect(request.ResolveContext()).To(Equal(model.AnalysisContext{UnderlyingPrice: 100, Carry: model.Carry{RiskFreeRate: 0.05}}))
+
+		request.Context = &model.AnalysisContext{UnderlyingPrice: 110, Carry: model.Carry{RiskFreeRate: 0.03, DividendYield: 0.02}}
+		Expect(request.ResolveContext()).To(Equal(*request.Context))
+	})
+
+	It("should reject a context that contradicts the top-level underlying price or risk-free rate", func() {
+		request := model.AnalysisRequest{UnderlyingPrice: 100, RiskFreeRate: 0.05, Context: &model.AnalysisContext{UnderlyingPrice: 110}}
+		errs := model.ValidateAnalysisRequest(request)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("underlying_price"))
+		Expect(errs[0].Code).To(Equal(model.CodeConflict))
+
+		request.Context = &model.AnalysisContext{UnderlyingPrice: 100, Carry: model.Carry{RiskFreeRate: 0.03}}
+		Expect(model.IsAnalysisRequestValid(request)).To(MatchError("risk-free rate does not match the context"))
+
+		// The same values, or a context leaving them out, do not conflict
+		request.Context = &model.AnalysisContext{UnderlyingPrice: 100, Carry: model.Carry{DividendYield: 0.02}}
+		Expect(model.IsAnalysisRequestValid(request)).To(BeNil())
+	})
+
+	It("should not take a dividend yield and discrete dividends together", func() {
+		context := model.AnalysisContext{Carry: model.Carry{DividendYield: 0.02, Dividends: []model.Dividend{{ExDate: expiration, Amount: 1}}}}
+		Expect(model.IsAnalysisContextValid(context)).To(MatchError("dividend yield and dividends cannot both be given"))
+
+		context.DividendYield = 0
+		Expect(model.IsAnalysisContextValid(context)).To(BeNil())
+	})
+
+	It("should keep the put-call parity with a dividend yield", func() {
+		market := pricing.Market{Volatility: 0.2, Carry: model.Carry{RiskFreeRate: 0.05, DividendYield: 0.03}}
+		years := pricing.YearsToExpiry(call, now)
+		parity := 100*math.Exp(-0.03*years) - 100*math.Exp(-0.05*years)
+
+		Expect(pricing.ContractValue(call, 100, market, now) - pricing.ContractValue(put, 100, market, now)).To(BeNumerically("~", parity, 1e-9))
+	})
+
+	It("should escrow the discrete dividends out of every model calculation", func() {
+		market := pricing.Market{Volatility: 0.2, Carry: model.Carry{RiskFreeRate: 0.05, Dividends: []model.Dividend{{ExDate: now.AddDate(0, 6, 0), Amount: 3}}}}
+		dividends := pricing.ScheduleDividends(market.Dividends, now, call.ExpiresAt())
+		escrowed := pricing.EscrowedSpot(100, dividends, 0.05)
+		Expect(escrowed).To(BeNumerically("~", 100-3*math.Exp(-0.05*pricing.YearsBetween(now, now.AddDate(0, 6, 0))), 1e-9))
+
+		// The value and greeks are those of an option on the escrowed spot
+		noDividends := pricing.Market{Volatility: 0.2, Carry: model.Carry{RiskFreeRate: 0.05}}
+		Expect(pricing.ContractValue(call, 100, market, now)).To(BeNumerically("~", pricing.ContractValue(call, escrowed, noDividends, now), 1e-9))
+		Expect(pricing.ContractGreeks(call, 100, market, now)).To(Equal(pricing.ContractGreeks(call, escrowed, noDividends, now)))
+
+		// The implied volatility of the dividend value gives back the volatility
+		premium := pricing.ContractValue(call, 100, market, now)
+		volatility, err := pricing.ContractImpliedVolatility(call, premium, 100, market.Carry, now)
+		Expect(err).To(BeNil())
+		Expect(volatility).To(BeNumerically("~", 0.2, 1e-6))
+
+		// The probabilities spread the escrowed spot
+		profile := analysis.BuildPayoffProfile([]model.OptionsContract{call})
+		probabilities := analysis.CalculateProbabilities(profile, 100, market, now, expiration)
+		Expect(probabilities).To(Equal(analysis.CalculateProbabilities(profile, escrowed, noDividends, now, expiration)))
+	})
+
+	It("should bump the tree for the greeks of an american option", func() {
+		market := pricing.Market{Volatility: 0.2, Carry: model.Carry{RiskFreeRate: 0.05}}
+		american := put
+		american.ExerciseStyle = model.American
+
+		europeanGreeks := pricing.ContractGreeks(put, 100, market, now)
+		americanGreeks := pricing.ContractGreeks(american, 100, market, now)
+		// The early exercise makes the put behave more like the short underlying
+		Expect(americanGreeks.Delta).To(BeNumerically("<", europeanGreeks.Delta))
+		Expect(americanGreeks.Delta).To(BeNumerically(">", -1))
+		Expect(americanGreeks.Vega).To(BeNumerically("~", europeanGreeks.Vega, 0.05))
+		Expect(americanGreeks.Theta).To(BeNumerically("<", 0))
+	})
+})
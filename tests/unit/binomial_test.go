@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pricing.BinomialTree", func() {
+	It("should converge to Black-Scholes for a european option", func() {
+		call := pricing.BinomialTree(model.Call, false, 100, 100, 1, 0.05, 0, 0.2, nil)
+		put := pricing.BinomialTree(model.Put, false, 100, 100, 1, 0.05, 0, 0.2, nil)
+
+		Expect(call).To(BeNumerically("~", pricing.BlackScholes(model.Call, 100, 100, 1, 0.05, 0.2), 0.02))
+		Expect(put).To(BeNumerically("~", pricing.BlackScholes(model.Put, 100, 100, 1, 0.05, 0.2), 0.02))
+	})
+
+	It("should add the early-exercise premium to an american put", func() {
+		Expect(pricing.BinomialTree(model.Put, true, 100, 100, 1, 0.05, 0, 0.2, nil)).To(BeNumerically("~", 6.09, 0.02))
+
+		// A deep in-the-money put is exercised right away
+		Expect(pricing.BinomialTree(model.Put, true, 50, 100, 1, 0.05, 0, 0.2, nil)).To(BeNumerically("~", 50, 1e-9))
+	})
+
+	It("should never exercise an american call early without dividends", func() {
+		american := pricing.BinomialTree(model.Call, true, 100, 100, 1, 0.05, 0, 0.2, nil)
+		european := pricing.BinomialTree(model.Call, false, 100, 100, 1, 0.05, 0, 0.2, nil)
+
+		Expect(american).To(BeNumerically("~", european, 1e-9))
+		Expect(pricing.EarlyExerciseBoundary(model.Call, 100, 100, 1, 0.05, 0, 0.2, nil)).To(BeEmpty())
+	})
+
+	It("should exercise an american call before a large dividend", func() {
+		dividends := []pricing.ScheduledDividend{{Years: 0.5, Amount: 10}}
+		american := pricing.BinomialTree(model.Call, true, 110, 100, 1, 0.05, 0, 0.2, dividends)
+		european := pricing.BinomialTree(model.Call, false, 110, 100, 1, 0.05, 0, 0.2, dividends)
+
+		Expect(american).To(BeNumerically(">", european+0.5))
+		boundary := pricing.EarlyExerciseBoundary(model.Call, 110, 100, 1, 0.05, 0, 0.2, dividends)
+		Expect(boundary).NotTo(BeEmpty())
+		Expect(boundary[0].Years).To(BeNumerically("<=", 0.5))
+		Expect(boundary[0].UnderlyingPrice).To(BeNumerically(">", 100))
+	})
+})
+
+var _ = Describe("American contracts", func() {
+	expiration := time.Now().AddDate(1, 0, 0)
+	put := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 6, ExpirationDate: expiration}
+	market := pricing.Market{Volatility: 0.2, Carry: model.Carry{RiskFreeRate: 0.05}}
+
+	It("should validate the exercise style", func() {
+		contract := put
+		contract.ExerciseStyle = "bermudan"
+		Expect(model.IsOptionsContractValid(contract)).To(MatchError("invalid exercise style. european or american"))
+
+		contract.ExerciseStyle = model.American
+		Expect(model.IsOptionsContractValid(contract)).To(BeNil())
+	})
+
+	It("should value an american contract on the tree", func() {
+		contract := put
+		contract.ExerciseStyle = model.American
+		now := time.Now()
+
+		Expect(pricing.ContractValue(contract, 100, market, now)).To(BeNumerically(">", pricing.ContractValue(put, 100, market, now)))
+	})
+
+	It("should report the early-exercise boundary of an american put below its strike", func() {
+		contract := put
+		contract.ExerciseStyle = model.American
+		boundaries := analysis.CalculateExerciseBoundaries([]model.OptionsContract{contract, put}, 100, market, time.Now())
+
+		Expect(boundaries).To(HaveLen(1))
+		Expect(boundaries[0].Boundary).NotTo(BeEmpty())
+		for _, point := range boundaries[0].Boundary {
+			Expect(point.UnderlyingPrice).To(BeNumerically("<", 100))
+			Expect(point.Date).To(BeTemporally("<", expiration))
+		}
+	})
+})
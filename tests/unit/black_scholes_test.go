@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pricing.BlackScholes", func() {
+	It("should price an at-the-money call and put", func() {
+		call := pricing.BlackScholes(model.Call, 100, 100, 1, 0.05, 0.2)
+		put := pricing.BlackScholes(model.Put, 100, 100, 1, 0.05, 0.2)
+
+		Expect(call).To(BeNumerically("~", 10.4506, 1e-4))
+		Expect(put).To(BeNumerically("~", 5.5735, 1e-4))
+	})
+
+	It("should return the intrinsic value at expiry", func() {
+		Expect(pricing.BlackScholes(model.Call, 110, 100, 0, 0.05, 0.2)).To(Equal(10.0))
+		Expect(pricing.BlackScholes(model.Put, 110, 100, 0, 0.05, 0.2)).To(Equal(0.0))
+	})
+})
+
+var _ = Describe("analysis.CalculateTheoreticalCurve", func() {
+	It("should match the expiry payoff once the contracts have expired", func() {
+		expiration := time.Now().AddDate(0, 1, 0)
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 10, Ask: 12, ExpirationDate: expiration},
+		}
+
+		curve := analysis.CalculateTheoreticalCurve(contracts, []float64{90, 120}, pricing.Market{Volatility: 0.3, Carry: model.Carry{RiskFreeRate: 0.05}}, expiration)
+		Expect(curve).To(Equal([]model.RiskRewardGraph{
+			{UnderlyingPrice: 90, ProfitLoss: -1200},
+			{UnderlyingPrice: 120, ProfitLoss: 800},
+		}))
+	})
+
+	It("should value the time remaining before expiry", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 10, Ask: 12, ExpirationDate: time.Now().AddDate(1, 0, 0)},
+		}
+
+		curve := analysis.CalculateTheoreticalCurve(contracts, []float64{90}, pricing.Market{Volatility: 0.3, Carry: model.Carry{RiskFreeRate: 0.05}}, time.Now())
+		Expect(curve[0].ProfitLoss).To(BeNumerically(">", -1200))
+	})
+})
@@ -40,7 +40,7 @@ var _ = Describe("analysis.CalculateBreakEvenPoints", func() {
 			}
 
 			breakEvenPoints := analysis.CalculateBreakEvenPoints(contracts)
-			Expect(breakEvenPoints).To(Equal([]float64{82.0}))
+			Expect(breakEvenPoints).To(Equal([]float64{80.0})) // The break-even point for a long put option is Strike Price - Ask
 		})
 
 		It("should calculate break-even points for multiple options contracts", func() {
@@ -19,7 +19,7 @@ var _ = Describe("CalculateMaxLossAndProfit", func() {
 		}
 
 		maxProfit, minLoss := analysis.CalculateMaxLossAndProfit(contracts)
-		expectedMaxProfit := 2604.0
+		expectedMaxProfit := 2065.0
 		expectedMinLoss := math.Inf(-1)
 
 		Expect(maxProfit).To(Equal(expectedMaxProfit))
@@ -35,14 +35,14 @@ var _ = Describe("CalculateMaxLossAndProfit", func() {
 		}
 
 		maxProfit, minLoss := analysis.CalculateMaxLossAndProfit(contracts)
-		expectedMaxProfit := 50.0 // Replace with the expected value
-		expectedMinLoss := -450.0 // Replace with the expected value
+		expectedMaxProfit := -250.0 // Bought at the ask and sold at the bid
+		expectedMinLoss := -750.0
 
 		Expect(maxProfit).To(BeNumerically("~", expectedMaxProfit, 1e-3))
 		Expect(minLoss).To(BeNumerically("~", expectedMinLoss, 1e-3))
 	})
 
-	It("should calculate both infinite maximum profit and minimum loss", func() {
+	It("should calculate an infinite maximum profit and a minimum loss bounded at zero", func() {
 		contracts := []model.OptionsContract{
 			{StrikePrice: 100, Type: model.Call, Ask: 12.0, Bid: 10.0, LongShort: model.Long},
 			{StrikePrice: 95, Type: model.Put, Ask: 9.0, Bid: 7.0, LongShort: model.Short},
@@ -51,6 +51,6 @@ var _ = Describe("CalculateMaxLossAndProfit", func() {
 		maxProfit, minLoss := analysis.CalculateMaxLossAndProfit(contracts)
 
 		Expect(math.IsInf(maxProfit, 1)).To(BeTrue())
-		Expect(math.IsInf(minLoss, -1)).To(BeTrue())
+		Expect(minLoss).To(BeNumerically("~", -10000, 1e-3)) // The underlying falls to zero, the put is assigned at 95 after a debit of 5
 	})
 })
@@ -0,0 +1,119 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/calendar"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trading calendar", func() {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	newYork := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, calendar.NewYork)
+	}
+
+	It("should observe the exchange holidays", func() {
+		name, holiday := calendar.Holiday(date(2025, time.April, 18))
+		Expect(holiday).To(BeTrue())
+		Expect(name).To(Equal("Good Friday"))
+
+		// Independence Day on a Saturday is observed on the Friday and Christmas on a Sunday on the Monday
+		Expect(calendar.IsTradingDay(date(2026, time.July, 3))).To(BeFalse())
+		Expect(calendar.IsTradingDay(date(2022, time.December, 26))).To(BeFalse())
+		Expect(calendar.IsTradingDay(date(2025, time.June, 19))).To(BeFalse())
+		Expect(calendar.IsTradingDay(date(2021, time.June, 18))).To(BeTrue())
+		// New Year's Day on a Saturday is not made up in the prior year
+		Expect(calendar.IsTradingDay(date(2021, time.December, 31))).To(BeTrue())
+		Expect(calendar.IsTradingDay(date(2025, time.November, 27))).To(BeFalse())
+		Expect(calendar.IsTradingDay(date(2025, time.December, 20))).To(BeFalse())
+		Expect(calendar.IsTradingDay(date(2025, time.December, 17))).To(BeTrue())
+	})
+
+	It("should close early on the eves of holidays", func() {
+		Expect(calendar.IsEarlyClose(date(2025, time.July, 3))).To(BeTrue())
+		Expect(calendar.IsEarlyClose(date(2025, time.November, 28))).To(BeTrue())
+		Expect(calendar.IsEarlyClose(date(2025, time.December, 24))).To(BeTrue())
+		Expect(calendar.IsEarlyClose(date(2025, time.December, 23))).To(BeFalse())
+		Expect(calendar.SessionClose(date(2025, time.November, 28))).To(Equal(newYork(2025, time.November, 28, 13, 0)))
+	})
+
+	It("should settle a bare expiration date at the close or open of its session", func() {
+		Expect(calendar.ExpiryInstant(date(2025, time.December, 17), calendar.PM)).To(BeTemporally("==", time.Date(2025, time.December, 17, 21, 0, 0, 0, time.UTC)))
+		Expect(calendar.ExpiryInstant(date(2025, time.December, 17), calendar.AM)).To(BeTemporally("==", time.Date(2025, time.December, 17, 14, 30, 0, 0, time.UTC)))
+		// An expiration on Good Friday rolls back to the Thursday and one on an early close settles at 1pm
+		Expect(calendar.ExpiryInstant(date(2025, time.April, 18), calendar.PM)).To(Equal(newYork(2025, time.April, 17, 16, 0)))
+		Expect(calendar.ExpiryInstant(date(2025, time.November, 28), calendar.PM)).To(Equal(newYork(2025, time.November, 28, 13, 0)))
+
+		// Midnight in New York sent in UTC is a bare date too, in winter and in summer
+		Expect(calendar.ExpiryInstant(time.Date(2025, time.December, 17, 5, 0, 0, 0, time.UTC), calendar.PM)).To(Equal(newYork(2025, time.December, 17, 16, 0)))
+		Expect(calendar.ExpiryInstant(time.Date(2025, time.June, 20, 4, 0, 0, 0, time.UTC), calendar.PM)).To(Equal(newYork(2025, time.June, 20, 16, 0)))
+		Expect(calendar.ExpiryInstant(time.Date(2025, time.December, 16, 21, 0, 0, 0, time.FixedZone("PST", -8*3600)), calendar.AM)).To(Equal(newYork(2025, time.December, 17, 9, 30)))
+
+		instant := time.Date(2025, time.December, 17, 18, 0, 0, 0, time.UTC)
+		Expect(calendar.ExpiryInstant(instant, calendar.PM)).To(Equal(instant))
+	})
+
+	It("should count the sessions left until the expiry", func() {
+		monday := newYork(2025, time.December, 15, 10, 0)
+		Expect(calendar.TradingDaysToExpiry(monday, calendar.ExpiryInstant(date(2025, time.December, 17), calendar.PM))).To(Equal(3))
+		// The session of an AM expiry opens at the settlement so it cannot be traded
+		Expect(calendar.TradingDaysToExpiry(monday, calendar.ExpiryInstant(date(2025, time.December, 19), calendar.AM))).To(Equal(4))
+		// The holidays and weekend are skipped
+		Expect(calendar.TradingDaysToExpiry(monday, calendar.ExpiryInstant(date(2026, time.January, 2), calendar.PM))).To(Equal(13))
+		Expect(calendar.TradingDaysToExpiry(newYork(2025, time.December, 17, 16, 30), calendar.ExpiryInstant(date(2025, time.December, 17), calendar.PM))).To(Equal(0))
+	})
+
+	It("should keep a contract expiring today valid until the close", func() {
+		contract := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 1, Ask: 1.1, ExpirationDate: date(2025, time.December, 17)}
+		Expect(model.IsOptionsContractValidAt(contract, newYork(2025, time.December, 17, 15, 0))).To(BeNil())
+		Expect(model.IsOptionsContractValidAt(contract, newYork(2025, time.December, 17, 16, 30))).To(MatchError("expiration date must be in the future"))
+
+		contract.Settlement = calendar.AM
+		Expect(model.IsOptionsContractValidAt(contract, newYork(2025, time.December, 17, 15, 0))).To(MatchError("expiration date must be in the future"))
+		// The last session of an AM contract is the day before, it cannot be traded after that close
+		Expect(model.IsOptionsContractValidAt(contract, newYork(2025, time.December, 16, 15, 0))).To(BeNil())
+		Expect(model.IsOptionsContractValidAt(contract, newYork(2025, time.December, 16, 17, 0))).To(MatchError("contract has no trading session left before its expiration"))
+		contract.Settlement = "noon"
+		Expect(model.IsOptionsContractValidAt(contract, newYork(2025, time.December, 16, 15, 0))).To(MatchError("invalid settlement. am or pm"))
+	})
+
+	It("should measure the time in sessions", func() {
+		friday := newYork(2025, time.December, 12, 12, 45)
+		// Half of the Friday session and all of the Monday one, the weekend counts for nothing
+		Expect(calendar.TradingTime(friday, newYork(2025, time.December, 15, 16, 0))).To(BeNumerically("~", 1.5, 1e-12))
+		Expect(calendar.TradingTime(newYork(2025, time.December, 13, 12, 0), newYork(2025, time.December, 15, 16, 0))).To(BeNumerically("~", 1, 1e-12))
+		// The early close of the day after Thanksgiving is a whole session in fewer hours
+		Expect(calendar.TradingTime(newYork(2025, time.November, 26, 16, 0), newYork(2025, time.November, 28, 13, 0))).To(BeNumerically("~", 1, 1e-12))
+		// A year and more, with its holidays
+		Expect(calendar.TradingTime(newYork(2024, time.December, 31, 16, 0), newYork(2025, time.December, 31, 16, 0))).To(BeNumerically("~", 251, 1e-9))
+
+		Expect(calendar.AddTradingTime(friday, 1.5)).To(BeTemporally("~", newYork(2025, time.December, 15, 16, 0), time.Millisecond))
+		Expect(calendar.AddTradingTime(friday, 0.25)).To(BeTemporally("~", newYork(2025, time.December, 12, 14, 22).Add(30*time.Second), time.Millisecond))
+	})
+
+	It("should stop the time value while the exchanges are closed", func() {
+		put := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 100, Bid: 1, Ask: 1.1, ExpirationDate: date(2025, time.December, 19)}
+		market := pricing.Market{Volatility: 0.2}
+
+		fridayClose := pricing.ContractValue(put, 100, market, newYork(2025, time.December, 12, 16, 0))
+		Expect(pricing.ContractValue(put, 100, market, newYork(2025, time.December, 14, 20, 0))).To(Equal(fridayClose))
+		Expect(pricing.YearsToExpiry(put, newYork(2025, time.December, 15, 9, 30))).To(BeNumerically("~", 5/pricing.TRADING_DAYS_PER_YEAR, 1e-12))
+	})
+
+	It("should value the time left until the settlement instant", func() {
+		contract := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 100, Bid: 1, Ask: 1.1, ExpirationDate: date(2025, time.December, 17)}
+		expirations := analysis.CalculateExpirations([]model.OptionsContract{contract}, newYork(2025, time.December, 17, 10, 0))
+
+		Expect(expirations).To(HaveLen(1))
+		Expect(expirations[0].Settlement).To(Equal(calendar.PM))
+		Expect(expirations[0].TradingDays).To(Equal(1))
+		Expect(expirations[0].ExpiresAt).To(Equal(newYork(2025, time.December, 17, 16, 0)))
+	})
+})
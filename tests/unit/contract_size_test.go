@@ -0,0 +1,65 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Contract quantity and multiplier", func() {
+	It("should default to a single contract of 100 shares", func() {
+		contract := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100}
+
+		Expect(contract.ContractQuantity()).To(Equal(1))
+		Expect(contract.ContractMultiplier()).To(Equal(100.0))
+		Expect(contract.Units()).To(Equal(100.0))
+	})
+
+	It("should reject a negative quantity or multiplier", func() {
+		contract := model.OptionsContract{
+			Type:           model.Call,
+			LongShort:      model.Long,
+			StrikePrice:    100.0,
+			Bid:            10.0,
+			Ask:            12.0,
+			ExpirationDate: time.Now().AddDate(0, 1, 0),
+		}
+		Expect(model.IsOptionsContractValid(contract)).To(BeNil())
+
+		contract.Quantity = -1
+		Expect(model.IsOptionsContractValid(contract)).To(MatchError("quantity must be non-negative"))
+
+		contract.Quantity = 1
+		contract.Multiplier = -10
+		Expect(model.IsOptionsContractValid(contract)).To(MatchError("multiplier must be non-negative"))
+	})
+
+	It("should scale a ratio spread by the quantity of every leg", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 100, Type: model.Call, Ask: 5, Bid: 5, LongShort: model.Long, Quantity: 10},
+			{StrikePrice: 110, Type: model.Call, Ask: 2, Bid: 2, LongShort: model.Short, Quantity: 20},
+		}
+
+		maxProfit, maxLoss := analysis.CalculateMaxLossAndProfit(contracts)
+
+		Expect(maxProfit).To(Equal(9000.0))
+		Expect(math.IsInf(maxLoss, -1)).To(BeTrue())
+		Expect(analysis.CalculateBreakEvenPoints(contracts)).To(Equal([]float64{101, 119}))
+	})
+
+	It("should scale by the contract multiplier", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 100, Type: model.Call, Ask: 3, Bid: 3, LongShort: model.Long, Quantity: 2, Multiplier: 10},
+		}
+
+		_, maxLoss := analysis.CalculateMaxLossAndProfit(contracts)
+
+		Expect(maxLoss).To(Equal(-60.0))
+		Expect(analysis.CalculateEntryPoint(contracts)).To(Equal(60.0))
+		Expect(analysis.CalculateTotalProfit(contracts, 110)).To(Equal(140.0))
+	})
+})
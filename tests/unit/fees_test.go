@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fees", func() {
+	It("should charge the per-order fee once and the per-leg minimum on small legs", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 5, Quantity: 10},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 110, Bid: 2, Ask: 2},
+		}
+		fees := model.FeeSchedule{PerContract: 0.5, PerOrder: 1, PerLegMinimum: 1}
+
+		Expect(analysis.CalculateEntryFees(contracts, fees)).To(Equal(7.0))
+	})
+
+	It("should charge the exercise and assignment fees only in the money", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Put, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 5, Quantity: 2},
+			{Type: model.Put, LongShort: model.Short, StrikePrice: 90, Bid: 2, Ask: 2},
+		}
+		fees := model.FeeSchedule{Assignment: 5, Exercise: 1}
+
+		Expect(analysis.CalculateSettlementFees(contracts, fees, 100)).To(Equal(0.0))
+		Expect(analysis.CalculateSettlementFees(contracts, fees, 95)).To(Equal(2.0))
+		Expect(analysis.CalculateSettlementFees(contracts, fees, 85)).To(Equal(7.0))
+	})
+
+	It("should report the gross and net results of a long call", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 5},
+		}
+		fees := model.FeeSchedule{PerContract: 0.65, PerOrder: 1, Exercise: 5}
+
+		feeAnalysis := analysis.CalculateFeeAnalysis(contracts, fees)
+
+		Expect(feeAnalysis.EntryFees).To(Equal(1.65))
+		Expect(feeAnalysis.GrossMaxLoss).To(Equal("-500.00"))
+		Expect(feeAnalysis.NetMaxLoss).To(Equal("-506.65"))
+		Expect(feeAnalysis.GrossBreakEvenPoints).To(Equal([]float64{105}))
+		Expect(feeAnalysis.NetBreakEvenPoints).To(Equal([]float64{105.07}))
+		Expect(feeAnalysis.NetMaxProfit).To(Equal("+Inf"))
+	})
+
+	It("should find a break-even where the assignment fee jumps across zero", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 100, Bid: 0.02, Ask: 0.02},
+		}
+		fees := model.FeeSchedule{Assignment: 5}
+
+		feeAnalysis := analysis.CalculateFeeAnalysis(contracts, fees)
+
+		Expect(feeAnalysis.GrossBreakEvenPoints).To(Equal([]float64{100.02}))
+		Expect(feeAnalysis.NetBreakEvenPoints).To(Equal([]float64{100}))
+		Expect(feeAnalysis.NetMaxProfit).To(Equal("2.00"))
+	})
+})
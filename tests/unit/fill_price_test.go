@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("analysis.CalculateFillPrice", func() {
+	long := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 100, Bid: 4, Ask: 5}
+	short := model.OptionsContract{Type: model.Put, LongShort: model.Short, StrikePrice: 100, Bid: 4, Ask: 5}
+
+	It("should buy at the ask and sell at the bid for a natural fill", func() {
+		Expect(analysis.CalculateFillPrice(long, model.Natural)).To(Equal(5.0))
+		Expect(analysis.CalculateFillPrice(short, model.Natural)).To(Equal(4.0))
+	})
+
+	It("should fill at the midpoint for a mid fill", func() {
+		Expect(analysis.CalculateFillPrice(long, model.Mid)).To(Equal(4.5))
+		Expect(analysis.CalculateFillPrice(short, model.Mid)).To(Equal(4.5))
+	})
+
+	It("should take the worse side of crossed quotes for a worst fill", func() {
+		crossed := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 6, Ask: 5}
+		Expect(analysis.CalculateFillPrice(crossed, model.Natural)).To(Equal(5.0))
+		Expect(analysis.CalculateFillPrice(crossed, model.Worst)).To(Equal(6.0))
+	})
+
+	It("should price a request at the worst fill once crossed quotes are let through", func() {
+		request := model.AnalysisRequest{
+			Contracts: []model.OptionsContract{{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 6, Ask: 5, ExpirationDate: time.Now().AddDate(0, 1, 0)}},
+			FillMode:  model.Worst,
+			Sanity:    &model.SanitySettings{Rules: map[model.SanityRuleName]model.RuleSeverity{model.RuleBidAsk: model.SeverityWarning}},
+		}
+		Expect(model.IsAnalysisRequestValid(request)).To(BeNil())
+
+		result := analysis.AnalyzeRequest(request, model.SystemClock{})
+		Expect(result.FillMode).To(Equal(model.Worst))
+		Expect(result.NetPremium).To(Equal(600.0))
+		Expect(result.Warnings).To(ContainElement(HaveField("Rule", model.RuleBidAsk)))
+	})
+
+	It("should prefer an explicit fill price", func() {
+		fillPrice := 4.2
+		contract := long
+		contract.FillPrice = &fillPrice
+		Expect(analysis.CalculateFillPrice(contract, model.Mid)).To(Equal(4.2))
+	})
+
+	It("should price the entry and the payoff with the same fill", func() {
+		contracts := []model.OptionsContract{long, short}
+		contracts[1].StrikePrice = 90
+		analysis.ApplyFillMode(contracts, model.Mid)
+
+		Expect(analysis.CalculateEntryPoint(contracts)).To(Equal(0.0))
+		Expect(analysis.CalculateTotalProfit(contracts, 120)).To(Equal(0.0))
+		Expect(analysis.CalculateProfitLoss(120, analysis.CalculateEntryPoint(contracts), contracts)).To(Equal(0.0))
+	})
+})
@@ -0,0 +1,46 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pricing.CalculateGreeks", func() {
+	It("should calculate the greeks of an at-the-money call", func() {
+		greeks := pricing.CalculateGreeks(model.Call, 100, 100, 1, 0.05, 0.2)
+
+		Expect(greeks.Delta).To(BeNumerically("~", 0.6368, 1e-4))
+		Expect(greeks.Gamma).To(BeNumerically("~", 0.018762, 1e-6))
+		Expect(greeks.Theta).To(BeNumerically("~", -6.4140/pricing.TRADING_DAYS_PER_YEAR, 1e-5))
+		Expect(greeks.Vega).To(BeNumerically("~", 0.37524, 1e-5))
+		Expect(greeks.Rho).To(BeNumerically("~", 0.53232, 1e-5))
+	})
+
+	It("should calculate the delta of an at-the-money put", func() {
+		greeks := pricing.CalculateGreeks(model.Put, 100, 100, 1, 0.05, 0.2)
+		Expect(greeks.Delta).To(BeNumerically("~", -0.3632, 1e-4))
+	})
+})
+
+var _ = Describe("analysis.CalculatePositionGreeks", func() {
+	It("should sum the signed greeks of every leg", func() {
+		expiration := time.Now().AddDate(0, 3, 0)
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 4, Ask: 5, ExpirationDate: expiration},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 100, Bid: 4, Ask: 5, ExpirationDate: expiration},
+		}
+
+		greeks := analysis.CalculatePositionGreeks(contracts, 100, pricing.Market{Volatility: 0.25, Carry: model.Carry{RiskFreeRate: 0.05}}, time.Now())
+
+		Expect(greeks.Legs).To(HaveLen(2))
+		Expect(greeks.Legs[0].Delta).To(BeNumerically(">", 0))
+		Expect(greeks.Legs[1].Delta).To(BeNumerically("<", 0))
+		Expect(greeks.Total.Delta).To(BeNumerically("~", 0, 1e-9))
+		Expect(greeks.Total.Vega).To(BeNumerically("~", 0, 1e-9))
+	})
+})
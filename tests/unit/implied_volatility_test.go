@@ -0,0 +1,66 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pricing.ImpliedVolatility", func() {
+	It("should recover the volatility used to price the option", func() {
+		for _, volatility := range []float64{0.05, 0.35, 1.5} {
+			premium := pricing.BlackScholes(model.Put, 100, 90, 0.5, 0.03, volatility)
+
+			impliedVolatility, err := pricing.ImpliedVolatility(model.Put, premium, 100, 90, 0.5, 0.03)
+			Expect(err).To(BeNil())
+			Expect(impliedVolatility).To(BeNumerically("~", volatility, 1e-6))
+		}
+	})
+
+	It("should fail for a premium below the intrinsic value", func() {
+		_, err := pricing.ImpliedVolatility(model.Call, 5, 120, 100, 0.5, 0.03)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should fail when the search does not converge", func() {
+		// At this scale a rounding step of the price is wider than the tolerance, so no volatility prices a premium between two steps
+		premium := pricing.BlackScholes(model.Call, 1e10, 1e10, 0.5, 0.03, 0.25) + 5e-6
+
+		_, err := pricing.ImpliedVolatility(model.Call, premium, 1e10, 1e10, 0.5, 0.03)
+		Expect(err).To(MatchError("implied volatility did not converge"))
+	})
+
+	It("should fail for an expired option", func() {
+		_, err := pricing.ImpliedVolatility(model.Call, 5, 100, 100, 0, 0.03)
+		Expect(err).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("analysis.CalculateImpliedVolatilities", func() {
+	It("should solve the bid, mid and ask of every leg", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 4, Ask: 5, ExpirationDate: time.Now().AddDate(0, 3, 0)},
+		}
+
+		volatilities := analysis.CalculateImpliedVolatilities(contracts, 100, model.Carry{RiskFreeRate: 0.05}, time.Now())
+
+		Expect(volatilities).To(HaveLen(1))
+		Expect(*volatilities[0].Bid).To(BeNumerically("<", *volatilities[0].Mid))
+		Expect(*volatilities[0].Mid).To(BeNumerically("<", *volatilities[0].Ask))
+	})
+
+	It("should leave out quotes that cannot be solved", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 0, Ask: 25, ExpirationDate: time.Now().AddDate(0, 3, 0)},
+		}
+
+		volatilities := analysis.CalculateImpliedVolatilities(contracts, 120, model.Carry{RiskFreeRate: 0.05}, time.Now())
+
+		Expect(volatilities[0].Bid).To(BeNil())
+		Expect(volatilities[0].Ask).NotTo(BeNil())
+	})
+})
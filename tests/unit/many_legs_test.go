@@ -0,0 +1,79 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dozens of legs", func() {
+	expiration := time.Now().AddDate(0, 1, 0)
+
+	// A ladder of calls and puts on both sides with varying quantities
+	ladder := func() []model.OptionsContract {
+		var contracts []model.OptionsContract
+		for i := 0; i < 36; i++ {
+			contract := model.OptionsContract{
+				Type:           model.Call,
+				LongShort:      model.Long,
+				StrikePrice:    float64(60 + 5*(i/2)),
+				Bid:            float64(i%7) + 0.5,
+				Ask:            float64(i%7) + 0.75,
+				ExpirationDate: expiration,
+				Quantity:       1 + i%3,
+			}
+			if i%2 == 1 {
+				contract.Type = model.Put
+			}
+			if i%3 == 0 || i%5 == 0 {
+				contract.LongShort = model.Short
+			}
+			contracts = append(contracts, contract)
+		}
+		return contracts
+	}
+
+	It("should find every break-even point and extreme of the payoff", func() {
+		contracts := ladder()
+		analysis.ApplyFillMode(contracts, model.Natural)
+		breakEvenPoints := analysis.CalculateBreakEvenPoints(contracts)
+		maxProfit, maxLoss := analysis.CalculateMaxLossAndProfit(contracts)
+
+		// Sample the payoff finely from zero, every strike falls on the grid
+		var signChanges int
+		sampledMax, sampledMin := math.Inf(-1), math.Inf(1)
+		previous := analysis.CalculateTotalProfit(contracts, 0)
+		for price := 0.0; price <= 200; price += 0.25 {
+			profitLoss := analysis.CalculateTotalProfit(contracts, price)
+			sampledMax, sampledMin = math.Max(sampledMax, profitLoss), math.Min(sampledMin, profitLoss)
+			if (previous < 0) != (profitLoss < 0) {
+				signChanges++
+				Expect(breakEvenPoints).To(ContainElement(BeNumerically("~", price, 0.25)))
+			}
+			previous = profitLoss
+		}
+		Expect(breakEvenPoints).To(HaveLen(signChanges))
+
+		// The ladder is net long calls so the profit is unbounded, while the loss is bounded with the price at zero or above
+		Expect(math.IsInf(maxProfit, 1)).To(BeTrue())
+		Expect(math.IsInf(maxLoss, 0)).To(BeFalse())
+		Expect(maxLoss).To(BeNumerically("~", sampledMin, 0.01))
+	})
+
+	It("should put every strike on the graph", func() {
+		result := analysis.AnalyzeContracts(ladder())
+
+		var prices []float64
+		for _, point := range result.RiskRewardGraph {
+			prices = append(prices, point.UnderlyingPrice)
+		}
+		for _, contract := range ladder() {
+			Expect(prices).To(ContainElement(BeNumerically("~", contract.StrikePrice, 1e-9)))
+		}
+		Expect(result.Strategy.Name).To(Equal(analysis.CUSTOM_STRATEGY))
+	})
+})
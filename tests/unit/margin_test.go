@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("analysis.CalculateMargin", func() {
+	expiration := time.Now().AddDate(0, 1, 0)
+	longCall := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 1.8, Ask: 2, ExpirationDate: expiration}
+	shortPut := model.OptionsContract{Type: model.Put, LongShort: model.Short, StrikePrice: 95, Bid: 2, Ask: 2.2, ExpirationDate: expiration}
+	longPut := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 90, Bid: 0.9, Ask: 1, ExpirationDate: expiration}
+	shortCall := model.OptionsContract{Type: model.Call, LongShort: model.Short, StrikePrice: 105, Bid: 1.5, Ask: 1.7, ExpirationDate: expiration}
+	wingCall := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 110, Bid: 0.4, Ask: 0.5, ExpirationDate: expiration}
+	shares := model.OptionsContract{Type: model.Stock, LongShort: model.Long, CostBasis: 100, Quantity: 100}
+
+	It("should require the premium of a long option", func() {
+		margin := analysis.CalculateMargin([]model.OptionsContract{longCall}, 100, math.Inf(1))
+
+		Expect(margin.Requirement).To(Equal(200.0))
+		Expect(margin.BuyingPowerEffect).To(Equal(200.0))
+		Expect(margin.ReturnOnCapital).To(BeNil())
+		Expect(margin.Components).To(HaveLen(1))
+		Expect(margin.Components[0].Treatment).To(Equal(model.MarginLong))
+	})
+
+	It("should require a share of the underlying for a naked option", func() {
+		// 20% of the spot less the 5 the put is out of the money, plus its premium
+		margin := analysis.CalculateMargin([]model.OptionsContract{shortPut}, 100, 200)
+
+		Expect(margin.Requirement).To(Equal(1700.0))
+		Expect(margin.BuyingPowerEffect).To(Equal(1500.0))
+		Expect(*margin.ReturnOnCapital).To(BeNumerically("~", 200.0/1500, 1e-9))
+		Expect(margin.Components[0].Treatment).To(Equal(model.MarginNaked))
+
+		// Far out of the money the floor of 10% of the strike applies
+		farPut := shortPut
+		farPut.StrikePrice = 50
+		Expect(analysis.NakedOptionMargin(farPut, 100)).To(Equal(5.0))
+	})
+
+	It("should relate the maximum profit of a short put to its buying power effect in the analysis", func() {
+		result := analysis.AnalyzeRequest(model.AnalysisRequest{Contracts: []model.OptionsContract{shortPut}, UnderlyingPrice: 100}, model.SystemClock{})
+
+		// The credit of 200 is the maximum profit, against the naked requirement less that credit
+		Expect(result.Margin).NotTo(BeNil())
+		Expect(result.Margin.BuyingPowerEffect).To(Equal(1500.0))
+		Expect(result.Margin.ReturnOnCapital).NotTo(BeNil())
+		Expect(*result.Margin.ReturnOnCapital).To(BeNumerically("~", 200.0/1500, 1e-9))
+	})
+
+	It("should require the width of a credit spread", func() {
+		margin := analysis.CalculateMargin([]model.OptionsContract{longPut, shortPut}, 100, 100)
+
+		// The buying power effect of a defined-risk spread is its maximum loss
+		Expect(margin.Requirement).To(Equal(600.0))
+		Expect(margin.BuyingPowerEffect).To(Equal(400.0))
+		Expect(*margin.ReturnOnCapital).To(BeNumerically("~", 0.25, 1e-9))
+		Expect(margin.Components).To(ContainElement(HaveField("Treatment", model.MarginSpread)))
+	})
+
+	It("should cover a short call with the shares", func() {
+		margin := analysis.CalculateMargin([]model.OptionsContract{shares, shortCall}, 100, 650)
+
+		Expect(margin.Requirement).To(Equal(5000.0))
+		Expect(margin.BuyingPowerEffect).To(Equal(4850.0))
+		Expect(margin.Components).To(ContainElement(HaveField("Treatment", model.MarginCovered)))
+	})
+
+	It("should only require the riskier side of an iron condor", func() {
+		margin := analysis.CalculateMargin([]model.OptionsContract{longPut, shortPut, shortCall, wingCall}, 100, 210)
+
+		// One 500 wide side plus the long wings, less the credit of the short options
+		Expect(margin.Requirement).To(Equal(650.0))
+		Expect(margin.BuyingPowerEffect).To(Equal(300.0))
+	})
+
+	It("should require the riskier side of a short strangle plus the premium of the other", func() {
+		margin := analysis.CalculateMargin([]model.OptionsContract{shortPut, shortCall}, 100, 350)
+
+		Expect(margin.Requirement).To(Equal(1850.0))
+		Expect(margin.BuyingPowerEffect).To(Equal(1500.0))
+	})
+})
@@ -0,0 +1,110 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mixed expirations", func() {
+	front := time.Now().AddDate(0, 0, 30)
+	back := time.Now().AddDate(0, 0, 60)
+
+	calendar := func() []model.OptionsContract {
+		return []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 100, Bid: 3, Ask: 3, ExpirationDate: front},
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 4.5, Ask: 4.5, ExpirationDate: back},
+		}
+	}
+
+	It("should detect contracts expiring on different dates", func() {
+		Expect(model.HasMixedExpirations(calendar())).To(BeTrue())
+
+		contracts := calendar()
+		contracts[1].ExpirationDate = front
+		Expect(model.HasMixedExpirations(contracts)).To(BeFalse())
+
+		// A stock leg never expires
+		contracts = append(contracts, model.OptionsContract{Type: model.Stock, LongShort: model.Long, CostBasis: 100})
+		Expect(model.HasMixedExpirations(contracts)).To(BeFalse())
+	})
+
+	It("should only require a volatility to evaluate at a requested date", func() {
+		Expect(model.IsAnalysisRequestValid(model.AnalysisRequest{Contracts: calendar()})).To(BeNil())
+
+		requested := front.AddDate(0, 0, -10)
+		request := model.AnalysisRequest{Contracts: calendar(), EvaluationDate: &requested}
+		Expect(model.IsAnalysisRequestValid(request)).To(MatchError("volatility is required to evaluate contracts before their expiration"))
+
+		request.Volatility = 0.2
+		Expect(model.IsAnalysisRequestValid(request)).To(BeNil())
+	})
+
+	It("should evaluate at the nearest expiration unless a date is requested", func() {
+		evaluationDate, ok := analysis.DetermineEvaluationDate(model.AnalysisRequest{Contracts: calendar(), Volatility: 0.2})
+		Expect(ok).To(BeTrue())
+		Expect(evaluationDate).To(Equal(front))
+
+		requested := front.AddDate(0, 0, -10)
+		evaluationDate, ok = analysis.DetermineEvaluationDate(model.AnalysisRequest{Contracts: calendar(), EvaluationDate: &requested, Volatility: 0.2})
+		Expect(ok).To(BeTrue())
+		Expect(evaluationDate).To(Equal(requested))
+
+		contracts := calendar()
+		contracts[1].ExpirationDate = front
+		_, ok = analysis.DetermineEvaluationDate(model.AnalysisRequest{Contracts: contracts, Volatility: 0.2})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should fall back to the expiry payoff with a warning without a volatility", func() {
+		_, ok := analysis.DetermineEvaluationDate(model.AnalysisRequest{Contracts: calendar()})
+		Expect(ok).To(BeFalse())
+
+		result := analysis.AnalyzeRequest(model.AnalysisRequest{Contracts: calendar()}, model.SystemClock{})
+
+		Expect(result.EvaluationDate).To(BeNil())
+		// Expiring together the two calls cancel out and the debit is lost at every price
+		Expect(result.MaxProfit).To(Equal("-150.00"))
+		Expect(result.MaxLoss).To(Equal("-150.00"))
+		// The quotes of the spread are locked, which is warned about too
+		Expect(result.Warnings).To(ContainElement(And(HaveField("Field", "volatility"), HaveField("Code", model.CodeRequired),
+			HaveField("Message", ContainSubstring("as if they all expired together")))))
+	})
+
+	It("should analyze a calendar spread at the front expiration", func() {
+		result, profile := analysis.AnalyzeContractsAtDate(calendar(), model.FeeSchedule{}, pricing.Market{Volatility: 0.2}, front)
+
+		// The debit is lost far from the strike and the profit peaks at the strike
+		Expect(result.MaxLoss).To(Equal("-150.00"))
+		Expect(result.MaxProfitPrices).To(HaveLen(1))
+		Expect(result.MaxProfitPrices[0]).To(BeNumerically("~", 100, 1))
+		Expect(result.BreakEvenPoints).To(HaveLen(2))
+		Expect(result.BreakEvenPoints[0]).To(BeNumerically("<", 100))
+		Expect(result.BreakEvenPoints[1]).To(BeNumerically(">", 100))
+		Expect(*result.EvaluationDate).To(Equal(front))
+
+		// The profile the analysis comes from is returned along with it
+		_, maxLoss, _, _ := profile.Extremes()
+		Expect(maxLoss).To(BeNumerically("~", -150, 0.01))
+	})
+
+	It("should match the expiry analysis when every contract has expired", func() {
+		contracts := calendar()
+		contracts[1].ExpirationDate = front
+		atExpiry, payoff := analysis.AnalyzeContractsWithFees(contracts, model.FeeSchedule{})
+		atDate, _ := analysis.AnalyzeContractsAtDate(contracts, model.FeeSchedule{}, pricing.Market{Volatility: 0.2}, front)
+		Expect(payoff).To(Equal(analysis.BuildNetPayoffProfile(contracts, model.FeeSchedule{})))
+
+		Expect(atDate.MaxProfit).To(Equal(atExpiry.MaxProfit))
+		Expect(atDate.MaxLoss).To(Equal(atExpiry.MaxLoss))
+		Expect(atDate.RiskRewardGraph).To(HaveLen(len(atExpiry.RiskRewardGraph)))
+		for i, point := range atExpiry.RiskRewardGraph {
+			Expect(atDate.RiskRewardGraph[i].UnderlyingPrice).To(Equal(point.UnderlyingPrice))
+			Expect(atDate.RiskRewardGraph[i].ProfitLoss).To(BeNumerically("~", point.ProfitLoss, 0.01))
+		}
+	})
+})
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Monte Carlo simulation", func() {
+	const spot, volatility, rate = 100.0, 0.2, 0.0
+	now := time.Now()
+	expiry := now.AddDate(0, 0, 30)
+	market := pricing.Market{Volatility: volatility, Carry: model.Carry{RiskFreeRate: rate}}
+
+	shortPut := []model.OptionsContract{{Type: model.Put, LongShort: model.Short, StrikePrice: 95, Bid: 1, Ask: 1, ExpirationDate: expiry}}
+
+	seed := func(seed int64) *int64 { return &seed }
+
+	simulate := func(settings model.SimulationSettings) model.SimulationAnalysis {
+		profile := analysis.BuildPayoffProfile(shortPut)
+		return analysis.SimulateContracts(shortPut, model.FeeSchedule{}, profile, settings, spot, market, now, expiry)
+	}
+
+	It("should repeat a run with the same seed", func() {
+		settings := model.SimulationSettings{Paths: 500, Seed: seed(42), JumpIntensity: 2, JumpMean: -0.05, JumpVolatility: 0.1}
+		Expect(simulate(settings)).To(Equal(simulate(settings)))
+		// A step per session
+		Expect(simulate(settings).Steps).To(Equal(int(math.Ceil(pricing.YearsBetween(now, expiry) * pricing.TRADING_DAYS_PER_YEAR))))
+	})
+
+	It("should agree with the lognormal distribution at expiry", func() {
+		result := simulate(model.SimulationSettings{Paths: 20000, Seed: seed(7)})
+		probabilities := analysis.CalculateProbabilities(analysis.BuildPayoffProfile(shortPut), spot, market, now, expiry)
+
+		// The break-even is touched at least as often as the position finishes beyond it
+		Expect(result.BreakEvenTouches).To(HaveLen(1))
+		Expect(result.BreakEvenTouches[0].BreakEvenPoint).To(Equal(94.0))
+		Expect(result.BreakEvenTouches[0].Probability).To(BeNumerically(">=", 1-probabilities.ProbabilityOfProfit-0.01))
+
+		// Without management every path is held to expiry, the median keeps the whole credit
+		Expect(result.ProbabilityOfProfitTarget).To(Equal(0.0))
+		Expect(result.ProbabilityOfStopLoss).To(Equal(0.0))
+		Expect(result.Percentiles).To(HaveLen(5))
+		Expect(result.Percentiles[2]).To(Equal(model.ProfitLossPercentile{Percentile: 50, ProfitLoss: 100}))
+		Expect(result.Percentiles[0].ProfitLoss).To(BeNumerically("<", 0))
+	})
+
+	It("should close the position at the profit target or the stop loss", func() {
+		// The stop loss is a fraction of the maximum loss of 9400 at zero, which a path rarely reaches
+		result := simulate(model.SimulationSettings{Paths: 2000, Seed: seed(7), ProfitTarget: 0.5, StopLoss: 0.1})
+
+		Expect(result.ProbabilityOfProfitTarget).To(BeNumerically(">", 0.5))
+		Expect(result.ProbabilityOfStopLoss).To(BeNumerically("<", 0.01))
+		// The position is closed on the first step past the target, never at the whole credit
+		Expect(result.Percentiles[3].ProfitLoss).To(BeNumerically(">=", 50))
+		Expect(result.Percentiles[4].ProfitLoss).To(BeNumerically("<", 100))
+	})
+
+	It("should stop out the short put at a fraction of its maximum loss at zero", func() {
+		// The maximum loss is 9400 when the underlying falls to zero, so the stop is at a loss of 188
+		result := simulate(model.SimulationSettings{Paths: 2000, Seed: seed(7), StopLoss: 0.02})
+
+		Expect(result.ProbabilityOfStopLoss).To(BeNumerically(">", 0.05))
+		Expect(result.ProbabilityOfProfitTarget).To(Equal(0.0))
+		// The stopped paths are closed near the stop instead of being held to expiry
+		Expect(result.Percentiles[0].ProfitLoss).To(BeNumerically(">", -400))
+	})
+
+	It("should follow the forward without any volatility", func() {
+		american := []model.OptionsContract{{Type: model.Put, LongShort: model.Short, StrikePrice: 95, Bid: 1, Ask: 1, ExpirationDate: expiry, ExerciseStyle: model.American}}
+		result := analysis.SimulateContracts(american, model.FeeSchedule{}, analysis.BuildPayoffProfile(american), model.SimulationSettings{Paths: 100, Seed: seed(1)}, spot, pricing.Market{}, now, expiry)
+
+		Expect(result.BreakEvenTouches[0].Probability).To(Equal(0.0))
+		for _, percentile := range result.Percentiles {
+			Expect(percentile.ProfitLoss).To(Equal(100.0))
+		}
+	})
+
+	It("should take a seed of zero as given", func() {
+		settings := model.SimulationSettings{Paths: 100, Seed: seed(0)}
+		Expect(simulate(settings).Seed).To(Equal(int64(0)))
+		Expect(simulate(settings)).To(Equal(simulate(settings)))
+	})
+
+	It("should keep the paths and steps within the budget of the contracts", func() {
+		condor := []model.OptionsContract{{Type: model.Put}, {Type: model.Put}, {Type: model.Call}, {Type: model.Call}}
+		Expect(model.SimulationSettings{}.MaxSteps(condor)).To(Equal(250))
+		Expect(model.SimulationSettings{Paths: 50000}.MaxSteps(condor)).To(Equal(100))
+		// Every american contract is tabulated on binomial trees at every step
+		condor[0].ExerciseStyle, condor[1].ExerciseStyle = model.American, model.American
+		Expect(model.SimulationSettings{}.MaxSteps(condor)).To(Equal(125))
+
+		// The default of a step per session is cut down to fit the budget
+		leap := []model.OptionsContract{{Type: model.Put, LongShort: model.Short, StrikePrice: 95, Bid: 1, Ask: 1, ExpirationDate: now.AddDate(2, 0, 0)}}
+		result := analysis.SimulateContracts(leap, model.FeeSchedule{}, analysis.BuildPayoffProfile(leap), model.SimulationSettings{Paths: 100}, spot, market, now, leap[0].ExpiresAt())
+		Expect(result.Steps).To(Equal(250))
+
+		// Explicit paths and steps beyond the budget fail the request
+		request := model.AnalysisRequest{Contracts: condor, UnderlyingPrice: spot, Volatility: volatility, Simulation: &model.SimulationSettings{Steps: 200}}
+		Expect(model.IsAnalysisRequestValid(request)).To(MatchError("too many simulation paths and steps for the contracts"))
+	})
+
+	It("should validate the simulation settings", func() {
+		Expect(model.IsSimulationSettingsValid(model.SimulationSettings{Paths: 200000})).To(MatchError("simulation paths must be at most 50000"))
+		Expect(model.IsSimulationSettingsValid(model.SimulationSettings{Steps: 1000})).To(MatchError("simulation steps must be at most 250"))
+		Expect(model.IsSimulationSettingsValid(model.SimulationSettings{ProfitTarget: -1})).To(MatchError("profit target and stop loss must be non-negative"))
+		Expect(model.IsSimulationSettingsValid(model.SimulationSettings{JumpMean: -0.1})).To(BeNil())
+	})
+})
@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OCC symbol", func() {
+	expiration := time.Date(2025, time.December, 17, 0, 0, 0, 0, time.UTC)
+
+	It("should parse a padded or unpadded symbol", func() {
+		symbol, err := model.ParseOCCSymbol("AAPL  251217C00100000")
+		Expect(err).To(BeNil())
+		Expect(symbol).To(Equal(model.OCCSymbol{Root: "AAPL", ExpirationDate: expiration, Type: model.Call, StrikePrice: 100}))
+
+		symbol, err = model.ParseOCCSymbol("spxw251217p05512500")
+		Expect(err).To(BeNil())
+		Expect(symbol.Root).To(Equal("SPXW"))
+		Expect(symbol.Type).To(Equal(model.Put))
+		Expect(symbol.StrikePrice).To(Equal(5512.5))
+		Expect(symbol.String()).To(Equal("SPXW  251217P05512500"))
+	})
+
+	It("should reject a malformed symbol", func() {
+		_, err := model.ParseOCCSymbol("AAPL 251217X00100000")
+		Expect(err).To(MatchError(`invalid OCC symbol "AAPL 251217X00100000". root, YYMMDD, C or P and the strike in thousandths`))
+		_, err = model.ParseOCCSymbol("AAPL  251317C00100000")
+		Expect(err).To(MatchError(`invalid OCC symbol "AAPL  251317C00100000". expiration is not a date`))
+	})
+
+	It("should fill in a contract from its symbol and generate the symbol of a contract with a root", func() {
+		contract := model.OptionsContract{LongShort: model.Long, Bid: 1, Ask: 1.1, Symbol: "aapl251217c00100000"}.ResolveSymbol()
+		Expect(contract.Type).To(Equal(model.Call))
+		Expect(contract.StrikePrice).To(Equal(100.0))
+		Expect(contract.ExpirationDate).To(Equal(expiration))
+		Expect(contract.Root).To(Equal("AAPL"))
+		Expect(contract.Symbol).To(Equal("AAPL  251217C00100000"))
+		Expect(model.IsOptionsContractValidAt(contract, expiration)).To(BeNil())
+
+		contract = model.OptionsContract{Type: model.Put, LongShort: model.Short, StrikePrice: 2.5, ExpirationDate: expiration, Root: "f"}.ResolveSymbol()
+		Expect(contract.Symbol).To(Equal("F     251217P00002500"))
+
+		stock := model.OptionsContract{Type: model.Stock, LongShort: model.Long, CostBasis: 100, Root: "AAPL"}.ResolveSymbol()
+		Expect(stock.Symbol).To(BeEmpty())
+	})
+
+	It("should report the fields that contradict the symbol", func() {
+		contract := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 105, Symbol: "AAPL  251217C00100000"}.ResolveSymbol()
+		errs := model.ValidateOptionsContract(contract, expiration)
+
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("type"))
+		Expect(errs[0].Code).To(Equal(model.CodeConflict))
+		Expect(errs[1].Field).To(Equal("strike_price"))
+		Expect(errs[1].Message).To(Equal("strike price does not match the symbol"))
+
+		contract = model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100, ExpirationDate: expiration, Root: "TOOLONGROOT"}
+		Expect(model.IsOptionsContractValidAt(contract, expiration)).To(MatchError("root must be 1 to 6 letters, digits or dots"))
+	})
+})
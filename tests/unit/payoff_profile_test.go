@@ -0,0 +1,101 @@
+package unit
+
+import (
+	"math"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("analysis.BuildPayoffProfile", func() {
+	It("should place a vertex at zero and at every distinct strike", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 95, Type: model.Put, Ask: 3.5, Bid: 2.5, LongShort: model.Short},
+			{StrikePrice: 100, Type: model.Put, Ask: 5.5, Bid: 4.0, LongShort: model.Long},
+			{StrikePrice: 100, Type: model.Call, Ask: 5.5, Bid: 4.0, LongShort: model.Long},
+			{StrikePrice: 105, Type: model.Call, Ask: 3.5, Bid: 2.5, LongShort: model.Short},
+		}
+
+		profile := analysis.BuildPayoffProfile(contracts)
+
+		Expect(profile.Vertices).To(HaveLen(4))
+		Expect(profile.Vertices[0].UnderlyingPrice).To(Equal(0.0))
+		Expect(profile.Vertices[0].ProfitLoss).To(BeNumerically("~", profile.Vertices[1].ProfitLoss, 1e-9))
+		Expect(profile.Vertices[1].UnderlyingPrice).To(Equal(95.0))
+		Expect(profile.Vertices[3].UnderlyingPrice).To(Equal(105.0))
+		Expect(profile.RightSlope).To(BeNumerically("~", 0, 1e-9))
+	})
+
+	It("should find the exact extremes and where they occur", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 100, Type: model.Call, Ask: 6, Bid: 6, LongShort: model.Long},
+			{StrikePrice: 110, Type: model.Call, Ask: 2, Bid: 2, LongShort: model.Short},
+		}
+
+		maxProfit, maxLoss, maxProfitPrices, maxLossPrices := analysis.BuildPayoffProfile(contracts).Extremes()
+
+		Expect(maxProfit).To(BeNumerically("~", 600, 1e-9))
+		Expect(maxLoss).To(BeNumerically("~", -400, 1e-9))
+		Expect(maxProfitPrices).To(Equal([]float64{110}))
+		Expect(maxLossPrices).To(Equal([]float64{100}))
+	})
+
+	It("should report unbounded extremes along the tails", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 100, Type: model.Call, Ask: 6, Bid: 6, LongShort: model.Short},
+		}
+
+		maxProfit, maxLoss, maxProfitPrices, maxLossPrices := analysis.BuildPayoffProfile(contracts).Extremes()
+
+		Expect(maxProfit).To(BeNumerically("~", 600, 1e-9))
+		Expect(maxProfitPrices).To(Equal([]float64{100}))
+		Expect(math.IsInf(maxLoss, -1)).To(BeTrue())
+		Expect(maxLossPrices).To(BeEmpty())
+	})
+
+	It("should bound the profit of a long put at a price of zero", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 100, Type: model.Put, Ask: 5, Bid: 4, LongShort: model.Long},
+		}
+
+		maxProfit, maxLoss, maxProfitPrices, maxLossPrices := analysis.BuildPayoffProfile(contracts).Extremes()
+
+		Expect(maxProfit).To(BeNumerically("~", 9500, 1e-9))
+		Expect(maxProfitPrices).To(Equal([]float64{0}))
+		Expect(maxLoss).To(BeNumerically("~", -500, 1e-9))
+		Expect(maxLossPrices).To(Equal([]float64{100}))
+	})
+
+	It("should bound the loss of a short put at a price of zero", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 100, Type: model.Put, Ask: 5, Bid: 4, LongShort: model.Short},
+		}
+
+		profile := analysis.BuildPayoffProfile(contracts)
+		maxProfit, maxLoss, maxProfitPrices, maxLossPrices := profile.Extremes()
+
+		Expect(maxProfit).To(BeNumerically("~", 400, 1e-9))
+		Expect(maxProfitPrices).To(Equal([]float64{100}))
+		Expect(maxLoss).To(BeNumerically("~", -9600, 1e-9))
+		Expect(maxLossPrices).To(Equal([]float64{0}))
+		Expect(profile.Roots()).To(HaveLen(1))
+		Expect(profile.Roots()[0]).To(BeNumerically("~", 96, 1e-9))
+	})
+
+	It("should find the exact root of every crossing segment", func() {
+		contracts := []model.OptionsContract{
+			{StrikePrice: 95, Type: model.Put, Ask: 2.5, Bid: 2.5, LongShort: model.Short},
+			{StrikePrice: 100, Type: model.Put, Ask: 4.0, Bid: 4.0, LongShort: model.Long},
+			{StrikePrice: 110, Type: model.Call, Ask: 9.5, Bid: 9.5, LongShort: model.Long},
+			{StrikePrice: 115, Type: model.Call, Ask: 6.5, Bid: 6.5, LongShort: model.Short},
+		}
+
+		roots := analysis.BuildPayoffProfile(contracts).Roots()
+
+		Expect(roots).To(HaveLen(2))
+		Expect(roots[0]).To(BeNumerically("~", 95.5, 1e-9))
+		Expect(roots[1]).To(BeNumerically("~", 114.5, 1e-9))
+	})
+})
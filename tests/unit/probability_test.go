@@ -0,0 +1,67 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Probabilities", func() {
+	const spot, volatility, rate, years = 100.0, 0.2, 0.05, 0.5
+	market := pricing.Market{Volatility: volatility, Carry: model.Carry{RiskFreeRate: rate}}
+	now := time.Now()
+	horizon := pricing.DateAfter(now, years)
+
+	// probabilityAbove is the lognormal probability of finishing above a price
+	probabilityAbove := func(price float64) float64 {
+		return 1 - pricing.LognormalCDF(price, spot, years, rate, 0, volatility)
+	}
+
+	It("should match the lognormal distribution", func() {
+		Expect(pricing.LognormalCDF(0, spot, years, rate, 0, volatility)).To(Equal(0.0))
+		Expect(pricing.LognormalCDF(math.Inf(1), spot, years, rate, 0, volatility)).To(Equal(1.0))
+		// The expected price grows at the risk-free rate
+		Expect(pricing.LognormalPartialExpectation(0, math.Inf(1), spot, years, rate, 0, volatility)).To(BeNumerically("~", spot*math.Exp(rate*years), 1e-9))
+	})
+
+	It("should calculate the probabilities of a long call", func() {
+		contracts := []model.OptionsContract{{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 5}}
+		result := analysis.CalculateProbabilities(analysis.BuildPayoffProfile(contracts), spot, market, now, horizon)
+
+		Expect(result.ProbabilityOfProfit).To(BeNumerically("~", probabilityAbove(105), 1e-9))
+		Expect(result.ProbabilityOfMaxProfit).To(Equal(0.0))
+		Expect(result.ProbabilityOfMaxLoss).To(BeNumerically("~", 1-probabilityAbove(100), 1e-9))
+
+		// The expected payoff is the undiscounted Black-Scholes value
+		callValue := pricing.BlackScholes(model.Call, spot, 100, years, rate, volatility) * math.Exp(rate*years)
+		Expect(result.ExpectedProfitLoss).To(BeNumerically("~", (callValue-5)*100, 0.01))
+	})
+
+	It("should calculate the probabilities of a bull call spread", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 5},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 110, Bid: 2, Ask: 2},
+		}
+		result := analysis.CalculateProbabilities(analysis.BuildPayoffProfile(contracts), spot, market, now, horizon)
+
+		Expect(result.ProbabilityOfProfit).To(BeNumerically("~", probabilityAbove(103), 1e-9))
+		Expect(result.ProbabilityOfMaxProfit).To(BeNumerically("~", probabilityAbove(110), 1e-9))
+		Expect(result.ProbabilityOfMaxLoss).To(BeNumerically("~", 1-probabilityAbove(100), 1e-9))
+	})
+
+	It("should settle at the forward without any volatility", func() {
+		contracts := []model.OptionsContract{{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 5, Ask: 5}}
+		flat := pricing.Market{Carry: model.Carry{RiskFreeRate: rate}}
+		result := analysis.CalculateProbabilities(analysis.BuildPayoffProfile(contracts), spot, flat, now, horizon)
+
+		forward := spot * math.Exp(rate*years)
+		Expect(result.ProbabilityOfProfit).To(Equal(0.0))
+		Expect(result.ProbabilityOfMaxLoss).To(Equal(0.0))
+		Expect(result.ExpectedProfitLoss).To(BeNumerically("~", (forward-105)*100, 0.01))
+	})
+})
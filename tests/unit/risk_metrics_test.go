@@ -0,0 +1,88 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("analysis.CalculateRiskMetrics", func() {
+	expiration := time.Now().AddDate(0, 1, 0)
+
+	It("should relate a credit spread to its width", func() {
+		metrics := analysis.AnalyzeContracts([]model.OptionsContract{
+			{Type: model.Put, LongShort: model.Short, StrikePrice: 95, Bid: 2, Ask: 2.2, ExpirationDate: expiration},
+			{Type: model.Put, LongShort: model.Long, StrikePrice: 90, Bid: 0.9, Ask: 1, ExpirationDate: expiration},
+		}).Metrics
+
+		Expect(*metrics.RiskRewardRatio).To(BeNumerically("~", 4, 1e-9))
+		Expect(*metrics.ReturnOnMaxRisk).To(BeNumerically("~", 0.25, 1e-9))
+		Expect(*metrics.Width).To(Equal(5.0))
+		Expect(*metrics.MaxProfitPercentOfWidth).To(BeNumerically("~", 20, 1e-9))
+		Expect(*metrics.CreditPercentOfWidth).To(BeNumerically("~", 20, 1e-9))
+		Expect(metrics.UnboundedProfit).To(BeFalse())
+		Expect(metrics.UnboundedLoss).To(BeFalse())
+	})
+
+	It("should measure a butterfly by the width of its wings", func() {
+		metrics := analysis.AnalyzeContracts([]model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 95, Bid: 5.8, Ask: 6, ExpirationDate: expiration},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 100, Bid: 3, Ask: 3.2, ExpirationDate: expiration, Quantity: 2},
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 105, Bid: 1.4, Ask: 1.5, ExpirationDate: expiration},
+		}).Metrics
+
+		Expect(*metrics.Width).To(Equal(5.0))
+		Expect(*metrics.MaxProfitPercentOfWidth).To(BeNumerically("~", 70, 1e-9))
+		Expect(metrics.CreditPercentOfWidth).To(BeNil())
+	})
+
+	It("should handle an unbounded profit", func() {
+		metrics := analysis.AnalyzeContracts([]model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 1.8, Ask: 2, ExpirationDate: expiration},
+		}).Metrics
+
+		Expect(metrics.UnboundedProfit).To(BeTrue())
+		Expect(*metrics.RiskRewardRatio).To(Equal(0.0))
+		Expect(metrics.ReturnOnMaxRisk).To(BeNil())
+		Expect(metrics.Width).To(BeNil())
+	})
+
+	It("should handle an unbounded loss", func() {
+		metrics := analysis.AnalyzeContracts([]model.OptionsContract{
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 100, Bid: 1.8, Ask: 2, ExpirationDate: expiration},
+		}).Metrics
+
+		Expect(metrics.UnboundedLoss).To(BeTrue())
+		Expect(metrics.RiskRewardRatio).To(BeNil())
+		Expect(*metrics.ReturnOnMaxRisk).To(Equal(0.0))
+	})
+
+	It("should bound the loss of a short put and a covered call at a price of zero", func() {
+		shortPut := analysis.AnalyzeContracts([]model.OptionsContract{
+			{Type: model.Put, LongShort: model.Short, StrikePrice: 95, Bid: 2, Ask: 2.2, ExpirationDate: expiration},
+		}).Metrics
+
+		Expect(shortPut.UnboundedLoss).To(BeFalse())
+		Expect(*shortPut.RiskRewardRatio).To(BeNumerically("~", 46.5, 1e-9))
+		Expect(*shortPut.ReturnOnMaxRisk).To(BeNumerically("~", 200.0/9300, 1e-9))
+
+		coveredCall := analysis.AnalyzeContracts([]model.OptionsContract{
+			{Type: model.Stock, LongShort: model.Long, CostBasis: 100, Quantity: 100},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 105, Bid: 2, Ask: 2.2, ExpirationDate: expiration},
+		}).Metrics
+
+		Expect(coveredCall.UnboundedLoss).To(BeFalse())
+		Expect(*coveredCall.RiskRewardRatio).To(BeNumerically("~", 9800.0/700, 1e-9))
+	})
+
+	It("should leave out the ratios of a position that cant lose", func() {
+		metrics := analysis.CalculateRiskMetrics(nil, 300, 100, -100)
+
+		Expect(metrics.UnboundedLoss).To(BeFalse())
+		Expect(metrics.RiskRewardRatio).To(BeNil())
+		Expect(metrics.ReturnOnMaxRisk).To(BeNil())
+	})
+})
@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Market Sanity", func() {
+	expiration := time.Now().AddDate(0, 1, 0)
+
+	rules := func(errs []model.ValidationError) []model.SanityRuleName {
+		var names []model.SanityRuleName
+		for _, err := range errs {
+			names = append(names, err.Rule)
+		}
+		return names
+	}
+
+	It("should fail a crossed quote and warn about the other rules by default", func() {
+		errs, warnings := model.CheckSanity(model.AnalysisRequest{
+			UnderlyingPrice: 120,
+			Contracts: []model.OptionsContract{
+				{Type: model.Call, LongShort: model.Long, StrikePrice: 115, Bid: 12, Ask: 11, ExpirationDate: expiration},
+				{Type: model.Call, LongShort: model.Short, StrikePrice: 110, Bid: 4, Ask: 4, ExpirationDate: expiration},
+				{Type: model.Put, LongShort: model.Long, StrikePrice: 5, Bid: 1, Ask: 6, ExpirationDate: expiration},
+			},
+		})
+
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Rule).To(Equal(model.RuleBidAsk))
+		Expect(errs[0].Code).To(Equal(model.CodeImplausible))
+		Expect(*errs[0].Leg).To(Equal(0))
+		Expect(errs[0].Field).To(Equal("contracts[0].bid"))
+		Expect(errs[0].Message).To(Equal("bid 12.00 is above the ask 11.00"))
+
+		// The short call is locked and below its intrinsic value of 10, the put is wide and worth more than its strike
+		Expect(rules(warnings)).To(Equal([]model.SanityRuleName{model.RuleLockedQuote, model.RuleSpreadWidth, model.RulePutPremium, model.RuleIntrinsicValue}))
+		Expect(*warnings[1].Leg).To(Equal(2))
+		Expect(warnings[1].Message).To(Equal("bid-ask spread is 142.86% of the mid, above 50.00%"))
+		Expect(*warnings[3].Leg).To(Equal(1))
+	})
+
+	It("should flag duplicate and offsetting legs", func() {
+		contract := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 100, Bid: 2, Ask: 2.1, ExpirationDate: expiration}
+		offset := contract
+		offset.LongShort = model.Short
+		later := contract
+		later.ExpirationDate = expiration.AddDate(0, 1, 0)
+
+		_, warnings := model.CheckSanity(model.AnalysisRequest{Contracts: []model.OptionsContract{contract, later, contract, offset}})
+
+		Expect(rules(warnings)).To(Equal([]model.SanityRuleName{model.RuleDuplicateLegs, model.RuleOffsettingLegs}))
+		Expect(warnings[0].Field).To(Equal("contracts[2]"))
+		Expect(warnings[0].Message).To(Equal("contract duplicates contract 0"))
+		Expect(warnings[1].Field).To(Equal("contracts[3].long_short"))
+		Expect(warnings[1].Message).To(Equal("contract offsets contract 0"))
+	})
+
+	It("should apply the configured severities and spread width", func() {
+		request := model.AnalysisRequest{
+			Contracts: []model.OptionsContract{
+				{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 12, Ask: 11, ExpirationDate: expiration},
+				{Type: model.Call, LongShort: model.Short, StrikePrice: 110, Bid: 4, Ask: 5, ExpirationDate: expiration},
+			},
+			Sanity: &model.SanitySettings{
+				Rules: map[model.SanityRuleName]model.RuleSeverity{
+					model.RuleBidAsk:      model.SeverityOff,
+					model.RuleSpreadWidth: model.SeverityError,
+				},
+				MaxSpreadPercent: 20,
+			},
+		}
+
+		errs, warnings := model.CheckSanity(request)
+		Expect(rules(errs)).To(Equal([]model.SanityRuleName{model.RuleSpreadWidth}))
+		Expect(*errs[0].Leg).To(Equal(1))
+		Expect(warnings).To(BeEmpty())
+		Expect(model.IsAnalysisRequestValid(request)).To(MatchError("bid-ask spread is 22.22% of the mid, above 20.00%"))
+	})
+
+	It("should validate the sanity settings", func() {
+		errs := model.ValidateSanitySettings(model.SanitySettings{
+			Rules:            map[model.SanityRuleName]model.RuleSeverity{"no_such_rule": model.SeverityWarning, model.RuleBidAsk: "fatal"},
+			MaxSpreadPercent: -1,
+		})
+
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0].Field).To(Equal("rules.bid_ask"))
+		Expect(errs[0].Message).To(Equal("invalid rule severity. error, warning or off"))
+		Expect(errs[1].Message).To(Equal("unknown sanity rule no_such_rule"))
+		Expect(errs[2].Field).To(Equal("max_spread_percent"))
+	})
+})
@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("analysis.AnalyzeScenarios", func() {
+	now := time.Now()
+	expiration := now.AddDate(0, 2, 0)
+	condor := func() []model.OptionsContract {
+		return []model.OptionsContract{
+			{Type: model.Put, LongShort: model.Long, StrikePrice: 85, Bid: 0.5, Ask: 0.6, ExpirationDate: expiration},
+			{Type: model.Put, LongShort: model.Short, StrikePrice: 90, Bid: 1.2, Ask: 1.3, ExpirationDate: expiration},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 110, Bid: 1.1, Ask: 1.2, ExpirationDate: expiration},
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 115, Bid: 0.4, Ask: 0.5, ExpirationDate: expiration},
+		}
+	}
+
+	It("should span the default axes with an expiry slice", func() {
+		grid := analysis.AnalyzeScenarios(model.ScenarioRequest{
+			Contracts:       condor(),
+			UnderlyingPrice: 100,
+			Volatility:      0.2,
+			Context:         &model.AnalysisContext{ValuationDate: &now},
+		}, model.SystemClock{})
+
+		Expect(grid.UnderlyingPrices).To(HaveLen(7))
+		Expect(grid.UnderlyingPrices[3].UnderlyingPrice).To(Equal(100.0))
+		Expect(grid.UnderlyingPrices[0].UnderlyingPrice).To(BeNumerically("<", grid.UnderlyingPrices[6].UnderlyingPrice))
+		Expect(grid.VolatilityShocks).To(Equal([]float64{0}))
+		Expect(grid.Dates).To(HaveLen(2))
+		Expect(grid.Dates[0].Expiry).To(BeFalse())
+		Expect(grid.Dates[1].Expiry).To(BeTrue())
+		Expect(grid.Dates[1].ValuationDate).To(BeTemporally("==", expiration))
+		Expect(grid.ProfitLoss).To(HaveLen(2))
+		Expect(grid.ProfitLoss[0]).To(HaveLen(1))
+		Expect(grid.ProfitLoss[0][0]).To(HaveLen(7))
+	})
+
+	It("should match the payoff at expiry", func() {
+		shocks := []float64{-0.1, 0, 0.1}
+		grid := analysis.AnalyzeScenarios(model.ScenarioRequest{
+			Contracts:       condor(),
+			UnderlyingPrice: 100,
+			Volatility:      0.2,
+			Axes:            model.ScenarioAxes{StandardDeviations: []float64{-2, 0, 2}, DaysForward: []int{0, 30}, VolatilityShocks: shocks},
+		}, model.FixedClock(now))
+
+		// Without a valuation date the grid starts at the time of the clock
+		Expect(grid.Dates[0].ValuationDate).To(BeTemporally("==", now))
+		Expect(grid.Dates[1].ValuationDate).To(BeTemporally("==", now.AddDate(0, 0, 30)))
+
+		contracts := condor()
+		analysis.ApplyFillMode(contracts, model.Natural)
+		expiry := grid.ProfitLoss[len(grid.ProfitLoss)-1]
+		for i := range shocks {
+			for j, move := range grid.UnderlyingPrices {
+				Expect(expiry[i][j]).To(BeNumerically("~", analysis.CalculateTotalProfit(contracts, move.UnderlyingPrice), 0.01))
+			}
+		}
+	})
+
+	It("should value the contracts with the shocked volatility", func() {
+		grid := analysis.AnalyzeScenarios(model.ScenarioRequest{
+			Contracts:       condor(),
+			UnderlyingPrice: 100,
+			Volatility:      0.2,
+			Axes:            model.ScenarioAxes{StandardDeviations: []float64{0}, VolatilityShocks: []float64{-0.1, 0, 0.1}},
+		}, model.FixedClock(now))
+
+		// A short condor loses when the volatility rises
+		today := grid.ProfitLoss[0]
+		Expect(today[0][0]).To(BeNumerically(">", today[1][0]))
+		Expect(today[1][0]).To(BeNumerically(">", today[2][0]))
+	})
+})
@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("analysis.ClassifyStrategy", func() {
+	expiration := time.Date(2030, 1, 18, 0, 0, 0, 0, time.UTC)
+	leg := func(optionType model.OptionType, position model.Position, strike, premium float64) model.OptionsContract {
+		return model.OptionsContract{Type: optionType, LongShort: position, StrikePrice: strike, Bid: premium, Ask: premium, ExpirationDate: expiration}
+	}
+
+	DescribeTable("should name the strategy and its bias",
+		func(contracts []model.OptionsContract, name string, bias model.Bias, premiumType model.PremiumType) {
+			strategy := analysis.ClassifyStrategy(contracts)
+
+			Expect(strategy.Name).To(Equal(name))
+			Expect(strategy.Bias).To(Equal(bias))
+			Expect(strategy.PremiumType).To(Equal(premiumType))
+		},
+		Entry("long call", []model.OptionsContract{
+			leg(model.Call, model.Long, 100, 5),
+		}, "Long Call", model.Bullish, model.Debit),
+		Entry("bull put spread", []model.OptionsContract{
+			leg(model.Put, model.Long, 95, 1), leg(model.Put, model.Short, 100, 3),
+		}, "Bull Put Spread", model.Bullish, model.Credit),
+		Entry("bear call spread", []model.OptionsContract{
+			leg(model.Call, model.Short, 100, 5), leg(model.Call, model.Long, 105, 2),
+		}, "Bear Call Spread", model.Bearish, model.Credit),
+		Entry("long straddle", []model.OptionsContract{
+			leg(model.Call, model.Long, 100, 5), leg(model.Put, model.Long, 100, 5),
+		}, "Long Straddle", model.Neutral, model.Debit),
+		Entry("short strangle", []model.OptionsContract{
+			leg(model.Put, model.Short, 90, 2), leg(model.Call, model.Short, 110, 2),
+		}, "Short Strangle", model.Neutral, model.Credit),
+		Entry("long call butterfly with a doubled body", []model.OptionsContract{
+			leg(model.Call, model.Long, 95, 7), leg(model.Call, model.Short, 100, 4), leg(model.Call, model.Short, 100, 4), leg(model.Call, model.Long, 105, 2),
+		}, "Long Call Butterfly", model.Neutral, model.Debit),
+		Entry("broken wing butterfly", []model.OptionsContract{
+			leg(model.Put, model.Long, 90, 1), leg(model.Put, model.Short, 100, 4), leg(model.Put, model.Short, 100, 4), leg(model.Put, model.Long, 105, 6),
+		}, "Long Put Broken Wing Butterfly", model.Bullish, model.Credit),
+		Entry("iron condor", []model.OptionsContract{
+			leg(model.Put, model.Long, 90, 1), leg(model.Put, model.Short, 95, 2), leg(model.Call, model.Short, 105, 2), leg(model.Call, model.Long, 110, 1),
+		}, "Iron Condor", model.Neutral, model.Credit),
+		Entry("jade lizard", []model.OptionsContract{
+			leg(model.Put, model.Short, 95, 3), leg(model.Call, model.Short, 105, 2), leg(model.Call, model.Long, 110, 1),
+		}, "Jade Lizard", model.Bullish, model.Credit),
+		Entry("synthetic long stock", []model.OptionsContract{
+			leg(model.Call, model.Long, 100, 5), leg(model.Put, model.Short, 100, 5),
+		}, "Synthetic Long Stock", model.Bullish, model.Even),
+		Entry("collar", []model.OptionsContract{
+			{Type: model.Stock, LongShort: model.Long, CostBasis: 100, Quantity: 100},
+			leg(model.Put, model.Long, 95, 2), leg(model.Call, model.Short, 105, 2),
+		}, "Collar", model.Bullish, model.Debit),
+	)
+
+	It("should name a calendar spread", func() {
+		back := leg(model.Call, model.Long, 100, 6)
+		back.ExpirationDate = expiration.AddDate(0, 1, 0)
+
+		Expect(analysis.NameStrategy([]model.OptionsContract{leg(model.Call, model.Short, 100, 4), back})).To(Equal("Call Calendar Spread"))
+	})
+
+	It("should fall back to a custom strategy", func() {
+		contracts := []model.OptionsContract{
+			leg(model.Call, model.Long, 100, 5), leg(model.Call, model.Long, 105, 3), leg(model.Put, model.Long, 90, 1),
+		}
+
+		Expect(analysis.NameStrategy(contracts)).To(Equal(analysis.CUSTOM_STRATEGY))
+	})
+})
@@ -0,0 +1,78 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stock and futures legs", func() {
+	It("should validate a stock leg without a strike or expiration", func() {
+		contract := model.OptionsContract{Type: model.Stock, LongShort: model.Long, CostBasis: 100, Quantity: 100}
+		Expect(model.IsOptionsContractValid(contract)).To(BeNil())
+
+		contract.CostBasis = 0
+		Expect(model.IsOptionsContractValid(contract)).To(MatchError("cost basis must be greater than zero"))
+	})
+
+	It("should require a multiplier for a futures leg", func() {
+		contract := model.OptionsContract{Type: model.Future, LongShort: model.Long, CostBasis: 4000, ExpirationDate: time.Now().AddDate(0, 3, 0)}
+		Expect(model.IsOptionsContractValid(contract)).To(MatchError("multiplier is required for futures"))
+
+		contract.Multiplier = 50
+		Expect(model.IsOptionsContractValid(contract)).To(BeNil())
+	})
+
+	It("should require an expiration date for a futures leg", func() {
+		contract := model.OptionsContract{Type: model.Future, LongShort: model.Long, CostBasis: 4000, Multiplier: 50}
+		errs := model.ValidateOptionsContract(contract, time.Now())
+
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("expiration_date"))
+		Expect(errs[0].Code).To(Equal(model.CodeRequired))
+		Expect(errs[0].Message).To(Equal("expiration date is required for futures"))
+	})
+
+	It("should analyze a covered call", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Stock, LongShort: model.Long, CostBasis: 100, Quantity: 100},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 105, Bid: 2, Ask: 2},
+		}
+
+		maxProfit, maxLoss := analysis.CalculateMaxLossAndProfit(contracts)
+
+		Expect(maxProfit).To(Equal(700.0))
+		Expect(maxLoss).To(Equal(-(100.0 - 2.0) * 100)) // The stock falls to zero, the premium offsets part of the cost basis
+		Expect(analysis.CalculateBreakEvenPoints(contracts)).To(Equal([]float64{98}))
+	})
+
+	It("should analyze a protective put", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Stock, LongShort: model.Long, CostBasis: 100, Quantity: 100},
+			{Type: model.Put, LongShort: model.Long, StrikePrice: 95, Bid: 3, Ask: 3},
+		}
+
+		maxProfit, maxLoss := analysis.CalculateMaxLossAndProfit(contracts)
+
+		Expect(math.IsInf(maxProfit, 1)).To(BeTrue())
+		Expect(maxLoss).To(Equal(-800.0))
+		Expect(analysis.CalculateBreakEvenPoints(contracts)).To(Equal([]float64{103}))
+	})
+
+	It("should scale a futures leg by its multiplier", func() {
+		contracts := []model.OptionsContract{
+			{Type: model.Future, LongShort: model.Long, CostBasis: 4000, Multiplier: 50},
+			{Type: model.Call, LongShort: model.Short, StrikePrice: 4100, Bid: 20, Ask: 20, Multiplier: 50},
+		}
+
+		maxProfit, _ := analysis.CalculateMaxLossAndProfit(contracts)
+
+		Expect(maxProfit).To(Equal(6000.0))
+		Expect(analysis.CalculateBreakEvenPoints(contracts)).To(Equal([]float64{3980}))
+		Expect(analysis.CalculateTotalProfit(contracts, 4050)).To(Equal(3500.0))
+	})
+})
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation", func() {
+	expiration := time.Now().AddDate(0, 1, 0)
+
+	It("should collect every error of every contract", func() {
+		errs := model.ValidateContracts([]model.OptionsContract{
+			{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 1, Ask: 1.2, ExpirationDate: expiration},
+			{Type: model.Put, LongShort: "InvalidPosition", StrikePrice: 95, Bid: -1, Ask: 1.2, ExpirationDate: expiration},
+			{Type: "InvalidType", LongShort: model.Short, StrikePrice: 90, Bid: 1, Ask: -1, ExpirationDate: expiration},
+		}, 4, time.Now())
+
+		Expect(errs).To(HaveLen(4))
+		Expect(*errs[0].Leg).To(Equal(1))
+		Expect(errs[0].Field).To(Equal("contracts[1].long_short"))
+		Expect(errs[0].Code).To(Equal(model.CodeInvalid))
+		Expect(errs[0].Message).To(Equal("invalid position type. long or short"))
+		Expect(errs[1].Field).To(Equal("contracts[1].bid"))
+		Expect(errs[1].Code).To(Equal(model.CodeNegative))
+		Expect(*errs[2].Leg).To(Equal(2))
+		Expect(errs[2].Field).To(Equal("contracts[2].type"))
+		Expect(errs[3].Field).To(Equal("contracts[2].ask"))
+	})
+
+	It("should check the number of contracts", func() {
+		errs := model.ValidateContracts(nil, 4, time.Now())
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Code).To(Equal(model.CodeEmpty))
+		Expect(errs[0].Leg).To(BeNil())
+
+		contract := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100, ExpirationDate: expiration}
+		errs = model.ValidateContracts([]model.OptionsContract{contract, contract, contract}, 2, time.Now())
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Code).To(Equal(model.CodeTooMany))
+		Expect(errs[0].Message).To(Equal("only accepting at most 2 options contracts"))
+	})
+
+	It("should locate the errors of nested inputs", func() {
+		errs := model.ValidateAnalysisRequest(model.AnalysisRequest{
+			Volatility: -0.2,
+			CurveDays:  []int{0, -7},
+			Context: &model.AnalysisContext{
+				Carry: model.Carry{Dividends: []model.Dividend{{ExDate: expiration, Amount: 0.5}, {Amount: -0.5}}},
+			},
+		})
+
+		var fields []string
+		for _, err := range errs {
+			fields = append(fields, err.Field)
+		}
+		Expect(fields).To(Equal([]string{"volatility", "curve_days[1]", "context.dividends[1].amount", "context.dividends[1].ex_date"}))
+		Expect(model.IsAnalysisRequestValid(model.AnalysisRequest{Volatility: -0.2})).To(MatchError("volatility must be non-negative"))
+	})
+})
@@ -0,0 +1,52 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/analysis"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Valuation date", func() {
+	valuationDate := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	expiration := time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC)
+	call := model.OptionsContract{Type: model.Call, LongShort: model.Long, StrikePrice: 100, Bid: 4, Ask: 4.2, ExpirationDate: expiration}
+
+	It("should check a contract as of the valuation time", func() {
+		Expect(model.IsOptionsContractValid(call)).To(MatchError("expiration date must be in the future"))
+		Expect(model.IsOptionsContractValidAt(call, valuationDate)).To(BeNil())
+		Expect(model.IsOptionsContractValidAt(call, expiration.AddDate(0, 0, 1))).To(MatchError("expiration date must be in the future"))
+	})
+
+	It("should resolve the valuation time from the context, the request or the clock", func() {
+		clock := model.FixedClock(expiration)
+		request := model.AnalysisRequest{}
+		Expect(request.ValuationTime(clock)).To(Equal(expiration))
+
+		request.ValuationDate = &valuationDate
+		Expect(request.ValuationTime(clock)).To(Equal(valuationDate))
+
+		contextDate := valuationDate.AddDate(0, 0, 7)
+		request.Context = &model.AnalysisContext{ValuationDate: &contextDate}
+		Expect(request.ValuationTime(clock)).To(Equal(contextDate))
+	})
+
+	It("should value expired contracts as of a past date", func() {
+		request := model.AnalysisRequest{
+			Contracts:       []model.OptionsContract{call},
+			UnderlyingPrice: 100,
+			Volatility:      0.2,
+			CurveDays:       []int{30},
+			ValuationDate:   &valuationDate,
+		}
+		Expect(model.ValidateContracts(request.Contracts, 4, request.ValuationTime(model.SystemClock{}))).To(BeEmpty())
+
+		result := analysis.AnalyzeRequest(request, model.SystemClock{})
+		Expect(result.TheoreticalCurves).To(HaveLen(1))
+		Expect(result.TheoreticalCurves[0].ValuationDate).To(Equal(valuationDate.AddDate(0, 0, 30)))
+		Expect(result.Greeks).NotTo(BeNil())
+		Expect(result.Greeks.Total.Theta).To(BeNumerically("<", 0))
+	})
+})
@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"math"
+	"time"
+
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/model"
+	"github.com/Aries-Financial-inc/golang-dev-logic-challenge-Oyal2/internal/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volatility surface", func() {
+	now := time.Now()
+	front := now.AddDate(0, 0, 30)
+	back := now.AddDate(0, 0, 90)
+	grid := model.VolatilitySurface{Points: []model.VolatilityPoint{
+		{StrikePrice: 110, ExpirationDate: front, Volatility: 0.18},
+		{StrikePrice: 90, ExpirationDate: front, Volatility: 0.30},
+		{StrikePrice: 100, ExpirationDate: front, Volatility: 0.20},
+		{StrikePrice: 100, ExpirationDate: back, Volatility: 0.30},
+	}}
+
+	It("should interpolate the strikes of an expiration", func() {
+		surface := pricing.NewSurface(grid)
+
+		Expect(surface.Volatility(100, front, now)).To(BeNumerically("~", 0.20, 1e-12))
+		Expect(surface.Volatility(95, front, now)).To(BeNumerically("~", 0.25, 1e-12))
+		// Flat beyond the quoted strikes
+		Expect(surface.Volatility(80, front, now)).To(BeNumerically("~", 0.30, 1e-12))
+		Expect(surface.Volatility(150, front, now)).To(BeNumerically("~", 0.18, 1e-12))
+	})
+
+	It("should interpolate the total variance between expirations", func() {
+		surface := pricing.NewSurface(grid)
+		middle := now.AddDate(0, 0, 60)
+
+		// 0.2^2 over 30 days and 0.3^2 over 90 days interpolated to 60 days, all in trading time
+		frontYears, middleYears, backYears := pricing.YearsBetween(now, front), pricing.YearsBetween(now, middle), pricing.YearsBetween(now, back)
+		frontVariance, backVariance := 0.04*frontYears, 0.09*backYears
+		expected := math.Sqrt((frontVariance + (backVariance-frontVariance)*(middleYears-frontYears)/(backYears-frontYears)) / middleYears)
+		Expect(surface.Volatility(100, middle, now)).To(BeNumerically("~", expected, 1e-9))
+		// Flat before the first and after the last expiration
+		Expect(surface.Volatility(100, now.AddDate(0, 0, 10), now)).To(BeNumerically("~", 0.20, 1e-12))
+		Expect(surface.Volatility(100, now.AddDate(1, 0, 0), now)).To(BeNumerically("~", 0.30, 1e-12))
+	})
+
+	It("should evaluate the SVI parameters", func() {
+		years := pricing.YearsBetween(now, front)
+		flat := model.SVISlice{ExpirationDate: front, Forward: 100, A: 0.04 * years, B: 0, Sigma: 0.1}
+		Expect(pricing.NewSurface(model.VolatilitySurface{SVI: []model.SVISlice{flat}}).Volatility(120, front, now)).To(BeNumerically("~", 0.2, 1e-9))
+
+		// A smile is higher in the wings than at the money
+		smile := model.SVISlice{ExpirationDate: front, Forward: 100, A: 0.002, B: 0.05, Rho: -0.3, M: 0, Sigma: 0.1}
+		surface := pricing.NewSurface(model.VolatilitySurface{SVI: []model.SVISlice{smile}})
+		Expect(surface.Volatility(80, front, now)).To(BeNumerically(">", surface.Volatility(100, front, now)))
+		Expect(surface.Volatility(120, front, now)).To(BeNumerically(">", surface.Volatility(100, front, now)))
+	})
+
+	It("should value every leg at its own volatility", func() {
+		market := pricing.Market{Volatility: 0.5, Surface: pricing.NewSurface(grid)}
+		wing := model.OptionsContract{Type: model.Put, LongShort: model.Long, StrikePrice: 90, ExpirationDate: front}
+		years := pricing.YearsToExpiry(wing, now)
+
+		Expect(market.LegVolatility(wing, now)).To(BeNumerically("~", 0.30, 1e-12))
+		Expect(pricing.ContractValue(wing, 100, market, now)).To(BeNumerically("~", pricing.BlackScholes(model.Put, 100, 90, years, 0, 0.30), 1e-9))
+		Expect(market.UnderlyingVolatility(100, front, now)).To(BeNumerically("~", 0.20, 1e-12))
+	})
+
+	It("should validate the surface", func() {
+		Expect(model.IsVolatilitySurfaceValid(model.VolatilitySurface{})).To(MatchError("volatility surface needs either points or svi slices"))
+		Expect(model.IsVolatilitySurfaceValid(grid)).To(BeNil())
+
+		invalid := model.VolatilitySurface{SVI: []model.SVISlice{{ExpirationDate: front, Forward: 100, A: 0.01, B: 0.1, Rho: 1, Sigma: 0.1}}}
+		Expect(model.IsVolatilitySurfaceValid(invalid)).To(MatchError("invalid svi parameters"))
+
+		// The underlying cant be spread without any volatility
+		flat := model.VolatilitySurface{Points: []model.VolatilityPoint{{StrikePrice: 100, ExpirationDate: front}}}
+		Expect(model.IsVolatilitySurfaceValid(flat)).To(MatchError("surface volatility must be positive"))
+		flatSVI := model.VolatilitySurface{SVI: []model.SVISlice{{ExpirationDate: front, Forward: 100, A: 0, B: 0, Sigma: 0.1}}}
+		Expect(model.IsVolatilitySurfaceValid(flatSVI)).To(MatchError("invalid svi parameters"))
+	})
+})